@@ -0,0 +1,141 @@
+// Package acpi decodes ACPI system description tables: the standard 36-byte
+// header shared by every table plus the licensing tables (MSDM and SLIC)
+// that carry OEM Windows activation data. Like smbios it is pure Go, so
+// captured tables can be decoded on any platform.
+package acpi
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+// HeaderSize is the size of the standard System Description Table Header.
+const HeaderSize = 36
+
+// Header is the standard header at the start of every ACPI table.
+type Header struct {
+	Signature       string
+	Length          uint32
+	Revision        byte
+	Checksum        byte
+	OEMID           string
+	OEMTableID      string
+	OEMRevision     uint32
+	CreatorID       string
+	CreatorRevision uint32
+}
+
+// Table is one ACPI table: its decoded header and the raw bytes, header included.
+type Table struct {
+	Header
+	Raw []byte
+	// Err is set when the table could not be read or parsed; Signature still names it.
+	Err error
+}
+
+// ParseTable decodes the header of a raw ACPI table.
+func ParseTable(raw []byte) (Table, error) {
+	if len(raw) < HeaderSize {
+		return Table{}, fmt.Errorf("ACPI table too short (%d bytes) for its header", len(raw))
+	}
+	t := Table{
+		Header: Header{
+			Signature:       string(raw[0:4]),
+			Length:          binary.LittleEndian.Uint32(raw[4:8]),
+			Revision:        raw[8],
+			Checksum:        raw[9],
+			OEMID:           string(raw[10:16]),
+			OEMTableID:      string(raw[16:24]),
+			OEMRevision:     binary.LittleEndian.Uint32(raw[24:28]),
+			CreatorID:       string(raw[28:32]),
+			CreatorRevision: binary.LittleEndian.Uint32(raw[32:36]),
+		},
+		Raw: raw,
+	}
+	if int(t.Length) > len(raw) {
+		return t, fmt.Errorf("%s table declares %d bytes but only %d were read", t.Signature, t.Length, len(raw))
+	}
+	return t, nil
+}
+
+// HasStandardHeader reports whether the table starts with the standard header. The
+// FACS only shares its signature and length fields and carries no checksum.
+func (t Table) HasStandardHeader() bool {
+	return t.Signature != "FACS"
+}
+
+// ChecksumValid reports whether the bytes covered by Length sum to zero, as the
+// specification requires. Tables edited without fixing the checksum fail this.
+func (t Table) ChecksumValid() bool {
+	if int(t.Length) > len(t.Raw) {
+		return false
+	}
+	var sum byte
+	for _, b := range t.Raw[:t.Length] {
+		sum += b
+	}
+	return sum == 0
+}
+
+// MSDM is the Microsoft Data Management table holding the OEM-embedded product key.
+type MSDM struct {
+	Version    uint32
+	DataType   uint32
+	ProductKey string
+}
+
+// DecodeMSDM extracts the software licensing structure of an MSDM table.
+func DecodeMSDM(t Table) (MSDM, error) {
+	if t.Signature != "MSDM" {
+		return MSDM{}, fmt.Errorf("table is %s, not MSDM", t.Signature)
+	}
+	// Version, Reserved, Data Type, Data Reserved, Data Length, then the key
+	const dataOffset = HeaderSize + 20
+	if len(t.Raw) < dataOffset {
+		return MSDM{}, fmt.Errorf("MSDM table too short (%d bytes)", len(t.Raw))
+	}
+	m := MSDM{
+		Version:  binary.LittleEndian.Uint32(t.Raw[HeaderSize : HeaderSize+4]),
+		DataType: binary.LittleEndian.Uint32(t.Raw[HeaderSize+8 : HeaderSize+12]),
+	}
+	dataLength := int(binary.LittleEndian.Uint32(t.Raw[HeaderSize+16 : HeaderSize+20]))
+	if dataOffset+dataLength > len(t.Raw) {
+		return m, fmt.Errorf("MSDM data length %d runs past the end of the table", dataLength)
+	}
+	m.ProductKey = strings.TrimRight(string(t.Raw[dataOffset:dataOffset+dataLength]), "\x00")
+	return m, nil
+}
+
+// SLIC is the Software Licensing Description Table used for OEM activation
+// (Windows Vista and 7). Its marker names the OEM it is tied to.
+type SLIC struct {
+	// Version is the marker version, e.g. "2.1".
+	Version     string
+	OEMID       string
+	OEMTableID  string
+	WindowsFlag string
+}
+
+// DecodeSLIC extracts the SLIC marker. The marker follows the 156-byte OEM
+// public key structure.
+func DecodeSLIC(t Table) (SLIC, error) {
+	if t.Signature != "SLIC" {
+		return SLIC{}, fmt.Errorf("table is %s, not SLIC", t.Signature)
+	}
+	const markerOffset = HeaderSize + 0x9C
+	if len(t.Raw) < markerOffset+34 {
+		return SLIC{}, fmt.Errorf("SLIC table too short (%d bytes) to hold a marker", len(t.Raw))
+	}
+	marker := t.Raw[markerOffset:]
+	if typ := binary.LittleEndian.Uint32(marker[0:4]); typ != 1 {
+		return SLIC{}, fmt.Errorf("SLIC marker structure has type %d, expected 1", typ)
+	}
+	version := binary.LittleEndian.Uint32(marker[8:12])
+	return SLIC{
+		Version:     fmt.Sprintf("%d.%d", version>>16, version&0xFFFF),
+		OEMID:       string(marker[12:18]),
+		OEMTableID:  string(marker[18:26]),
+		WindowsFlag: string(marker[26:34]),
+	}, nil
+}
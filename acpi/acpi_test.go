@@ -0,0 +1,161 @@
+package acpi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// readFixture returns a table from testdata, laid out as GetSystemFirmwareTable
+// returns it.
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	raw, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return raw
+}
+
+func parseFixture(t *testing.T, name string) Table {
+	t.Helper()
+	table, err := ParseTable(readFixture(t, name))
+	if err != nil {
+		t.Fatalf("ParseTable(%s): %v", name, err)
+	}
+	return table
+}
+
+func TestParseTable(t *testing.T) {
+	table := parseFixture(t, "msdm.bin")
+	want := Header{
+		Signature:       "MSDM",
+		Length:          85,
+		Revision:        3,
+		Checksum:        table.Raw[9],
+		OEMID:           "LENOVO",
+		OEMTableID:      "CB-01   ",
+		OEMRevision:     1,
+		CreatorID:       "ACPI",
+		CreatorRevision: 0x40000,
+	}
+	if table.Header != want {
+		t.Errorf("header = %+v, want %+v", table.Header, want)
+	}
+	if !table.ChecksumValid() {
+		t.Error("checksum of an unmodified table is invalid")
+	}
+
+	// A patched key without a fixed checksum
+	raw := readFixture(t, "msdm.bin")
+	raw[len(raw)-1] ^= 0x01
+	if patched, _ := ParseTable(raw); patched.ChecksumValid() {
+		t.Error("checksum still valid after the key was patched")
+	}
+}
+
+func TestParseTableTruncated(t *testing.T) {
+	raw := readFixture(t, "msdm.bin")
+	if _, err := ParseTable(raw[:HeaderSize-1]); err == nil {
+		t.Error("table shorter than the header parsed without error")
+	}
+	table, err := ParseTable(raw[:len(raw)-4])
+	if err == nil {
+		t.Error("table shorter than its declared length parsed without error")
+	}
+	if table.Signature != "MSDM" || table.ChecksumValid() {
+		t.Errorf("truncated table: signature %q, checksum valid %v", table.Signature, table.ChecksumValid())
+	}
+}
+
+func TestDecodeMSDM(t *testing.T) {
+	msdm, err := DecodeMSDM(parseFixture(t, "msdm.bin"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := MSDM{Version: 1, DataType: 1, ProductKey: "W269N-WFGWX-YVC9B-4J6C9-T83GX"}
+	if msdm != want {
+		t.Errorf("DecodeMSDM = %+v, want %+v", msdm, want)
+	}
+
+	// Data length pointing past the end of the table
+	table := parseFixture(t, "msdm.bin")
+	table.Raw[HeaderSize+16] = 0xFF
+	if _, err := DecodeMSDM(table); err == nil {
+		t.Error("data length past the end of the table decoded without error")
+	}
+	if _, err := DecodeMSDM(parseFixture(t, "slic.bin")); err == nil {
+		t.Error("SLIC table decoded as MSDM")
+	}
+}
+
+func TestDecodeSLIC(t *testing.T) {
+	table := parseFixture(t, "slic.bin")
+	if !table.ChecksumValid() {
+		t.Error("checksum of an unmodified table is invalid")
+	}
+	slic, err := DecodeSLIC(table)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := SLIC{Version: "2.1", OEMID: "LENOVO", OEMTableID: "TP-7K   ", WindowsFlag: "WINDOWS "}
+	if slic != want {
+		t.Errorf("DecodeSLIC = %+v, want %+v", slic, want)
+	}
+
+	// The public key structure where the marker should be
+	table.Raw[HeaderSize+0x9C] = 0
+	if _, err := DecodeSLIC(table); err == nil {
+		t.Error("marker with type 0 decoded without error")
+	}
+	if _, err := DecodeSLIC(Table{Header: Header{Signature: "SLIC"}, Raw: table.Raw[:HeaderSize+0x9C]}); err == nil {
+		t.Error("SLIC table without a marker decoded without error")
+	}
+}
+
+func TestFACSHasNoStandardHeader(t *testing.T) {
+	facs := parseFixture(t, "facs.bin")
+	if facs.HasStandardHeader() {
+		t.Error("FACS reported a standard header")
+	}
+	if facs.Length != 64 {
+		t.Errorf("FACS length = %d, want 64", facs.Length)
+	}
+	if !parseFixture(t, "msdm.bin").HasStandardHeader() {
+		t.Error("MSDM reported no standard header")
+	}
+}
+
+func TestSysfsSourceKeepsGoing(t *testing.T) {
+	dir := t.TempDir()
+	msdm := readFixture(t, "msdm.bin")
+	for name, raw := range map[string][]byte{
+		"MSDM": msdm,
+		"SLIC": readFixture(t, "slic.bin"),
+		"SSDT": msdm[:20],
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), raw, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tables, err := SysfsSource{Dir: dir}.ReadTables()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tables) != 3 {
+		t.Fatalf("got %d tables, want 3", len(tables))
+	}
+	// os.ReadDir sorts by name
+	for i, want := range []string{"MSDM", "SLIC", "SSDT"} {
+		if tables[i].Signature != want {
+			t.Errorf("table %d signature = %q, want %q", i, tables[i].Signature, want)
+		}
+	}
+	if tables[0].Err != nil || tables[1].Err != nil {
+		t.Errorf("intact tables have errors: %v, %v", tables[0].Err, tables[1].Err)
+	}
+	if tables[2].Err == nil {
+		t.Error("truncated SSDT has no error")
+	}
+}
@@ -0,0 +1,69 @@
+package acpi
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// DefaultSysfsDir is where Linux exposes the firmware's ACPI tables.
+const DefaultSysfsDir = "/sys/firmware/acpi/tables"
+
+// Source supplies every ACPI table of a machine.
+type Source interface {
+	Name() string
+	ReadTables() ([]Table, error)
+}
+
+// SysfsSource reads the ACPI tables exported by Linux, one file per table.
+type SysfsSource struct {
+	// Dir defaults to DefaultSysfsDir when empty.
+	Dir string
+}
+
+func (s SysfsSource) Name() string {
+	return "sysfs:" + s.dir()
+}
+
+func (s SysfsSource) dir() string {
+	if s.Dir == "" {
+		return DefaultSysfsDir
+	}
+	return s.Dir
+}
+
+func (s SysfsSource) ReadTables() ([]Table, error) {
+	entries, err := os.ReadDir(s.dir())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ACPI tables: %w", err)
+	}
+
+	var tables []Table
+	for _, entry := range entries {
+		// Skip the dynamic/ and data/ subdirectories
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		// One unreadable table does not stop the rest from being listed
+		raw, err := os.ReadFile(filepath.Join(s.dir(), entry.Name()))
+		if err != nil {
+			tables = append(tables, FailedTable(entry.Name(), fmt.Errorf("failed to read ACPI table %s: %w", entry.Name(), err)))
+			continue
+		}
+		t, err := ParseTable(raw)
+		if err != nil {
+			t.Err = fmt.Errorf("%s: %w", entry.Name(), err)
+			if t.Signature == "" {
+				t.Signature = entry.Name()
+			}
+		}
+		tables = append(tables, t)
+	}
+	return tables, nil
+}
+
+// FailedTable records a table that could not be read, under the signature it was
+// listed as.
+func FailedTable(signature string, err error) Table {
+	return Table{Header: Header{Signature: signature}, Err: err}
+}
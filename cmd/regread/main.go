@@ -0,0 +1,60 @@
+// Command regread prints every registry identifier DevSpoofGOTest collects, read from
+// offline hive files or a .reg export instead of the live registry. It builds on any OS,
+// so hives copied off a machine (reg save, or System32\config from an image) can be
+// checked from Linux.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/seekehr/DevSpoofGOTest/native"
+	"github.com/seekehr/DevSpoofGOTest/productkey"
+	"github.com/seekehr/DevSpoofGOTest/reg"
+)
+
+func main() {
+	registrySource := flag.String("registry", "", "registry source: hive:<dir> or a .reg file path")
+	flag.Parse()
+
+	if *registrySource == "" {
+		fmt.Fprintln(os.Stderr, "usage: regread -registry hive:<dir> | <file.reg>")
+		os.Exit(2)
+	}
+	if err := native.SetRegistry(*registrySource); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	green, red, cyan := color.GreenString, color.RedString, color.CyanString
+	str := green("=====Registry (" + native.Registry.Name() + ")=====")
+	for _, id := range native.RegistryIdentifiers {
+		str += "\n" + green(id.String()+": ")
+		v, err := native.ReadRegistryIdentifier(native.Registry, id)
+		if errors.Is(err, reg.ErrNotExist) {
+			str += cyan("Not Present")
+		} else if err != nil {
+			str += red("Error (" + err.Error() + ")")
+		} else {
+			str += v.String()
+		}
+	}
+
+	k, err := native.OpenCurrentVersion()
+	if err == nil {
+		defer k.Close()
+
+		productKey, err := native.GetProductKey(k)
+		if errors.Is(err, productkey.ErrNoKey) {
+			str += "\n" + green("Product Key: ") + cyan("Not Stored (digital license)")
+		} else if err != nil {
+			str += "\n" + red("Error decoding product key: "+err.Error())
+		} else {
+			str += "\n" + green("Product Key: ") + productKey
+		}
+	}
+	fmt.Println(str)
+}
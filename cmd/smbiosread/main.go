@@ -0,0 +1,49 @@
+// Command smbiosread prints an SMBIOS table read from Linux sysfs or a RawSMBIOSData dump
+// in dmidecode's text layout, or lists its structural anomalies. It builds on any OS, so a
+// Linux capture of a machine can be diffed against dmidecode on the same machine and
+// against DevSpoofGOTest's -dmi output on Windows.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/seekehr/DevSpoofGOTest/native"
+	"github.com/seekehr/DevSpoofGOTest/smbios"
+)
+
+func main() {
+	smbiosSource := flag.String("smbios", "sysfs", "SMBIOS source: sysfs[:dir] or a dump file path")
+	check := flag.Bool("check", false, "list structural anomalies instead of the dmidecode layout")
+	dumpPath := flag.String("dump", "", "also write the table as a RawSMBIOSData dump for -smbios <file>")
+	flag.Parse()
+
+	if err := native.SetSMBIOSSource(*smbiosSource); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	snapshot := native.TakeSMBIOSSnapshot()
+	if snapshot.Raw == nil {
+		fmt.Fprintln(os.Stderr, snapshot.Err)
+		os.Exit(1)
+	}
+	if *dumpPath != "" {
+		if err := smbios.WriteDump(*dumpPath, snapshot.Raw); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	if *check {
+		for _, anomaly := range smbios.CheckIntegrity(snapshot.Raw) {
+			fmt.Println(anomaly)
+		}
+		return
+	}
+	if snapshot.Table == nil {
+		fmt.Fprintln(os.Stderr, snapshot.Err)
+		os.Exit(1)
+	}
+	fmt.Print(smbios.RenderDmidecode(snapshot.Table, smbios.DmidecodeSourceLine(native.SMBIOSSource)))
+}
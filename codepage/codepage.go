@@ -0,0 +1,145 @@
+// Package codepage converts between Go strings and the Windows ANSI code pages
+// the A-variant APIs use, without calling into Windows.
+package codepage
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/korean"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+)
+
+// UTF8 is the code page GetACP reports when the system locale uses UTF-8.
+const UTF8 = 65001
+
+// ErrUnsupported is returned for code pages without a built-in encoding.
+var ErrUnsupported = errors.New("unsupported code page")
+
+// encodings are the Windows ANSI code pages: the single-byte pages and the
+// double-byte East Asian ones. Bytes a code page leaves undefined decode to
+// U+FFFD.
+var encodings = map[uint32]encoding.Encoding{
+	874:  charmap.Windows874,
+	932:  japanese.ShiftJIS,
+	936:  simplifiedchinese.GBK,
+	949:  korean.EUCKR,
+	950:  traditionalchinese.Big5,
+	1250: charmap.Windows1250,
+	1251: charmap.Windows1251,
+	1252: charmap.Windows1252,
+	1253: charmap.Windows1253,
+	1254: charmap.Windows1254,
+	1255: charmap.Windows1255,
+	1256: charmap.Windows1256,
+	1257: charmap.Windows1257,
+	1258: charmap.Windows1258,
+}
+
+// Supported reports whether cp can be decoded and encoded.
+func Supported(cp uint32) bool {
+	_, ok := encodings[cp]
+	return ok || cp == UTF8
+}
+
+// Decode converts bytes in code page cp to a string.
+func Decode(cp uint32, b []byte) (string, error) {
+	if cp == UTF8 {
+		return string(b), nil
+	}
+	enc, ok := encodings[cp]
+	if !ok {
+		return "", fmt.Errorf("%w %d", ErrUnsupported, cp)
+	}
+
+	s, err := enc.NewDecoder().Bytes(b)
+	if err != nil {
+		return "", fmt.Errorf("code page %d: %w", cp, err)
+	}
+	return string(s), nil
+}
+
+// Encode converts s to code page cp. Characters the code page cannot represent
+// become '?', one per UTF-16 unit, as WideCharToMultiByte's default character.
+func Encode(cp uint32, s string) ([]byte, error) {
+	if cp == UTF8 {
+		return []byte(s), nil
+	}
+	enc, ok := encodings[cp]
+	if !ok {
+		return nil, fmt.Errorf("%w %d", ErrUnsupported, cp)
+	}
+
+	encoder := enc.NewEncoder()
+	b := make([]byte, 0, len(s))
+	for _, r := range s {
+		if c, ok := encodeRune(encoder, r); ok {
+			b = append(b, c...)
+			continue
+		}
+		for range utf16Len(r) {
+			b = append(b, '?')
+		}
+	}
+	return b, nil
+}
+
+// Equivalent reports whether ansi, an A-variant result already decoded with
+// Decode, says the same thing as wide, the W-variant result. Characters of wide
+// that cp cannot represent match any character in ansi, since Windows may have
+// substituted '?' or a best-fit lookalike for them.
+func Equivalent(cp uint32, ansi, wide string) (bool, error) {
+	if cp == UTF8 {
+		return ansi == wide, nil
+	}
+	enc, ok := encodings[cp]
+	if !ok {
+		return false, fmt.Errorf("%w %d", ErrUnsupported, cp)
+	}
+
+	encoder := enc.NewEncoder()
+	for _, r := range wide {
+		if _, ok := encodeRune(encoder, r); ok {
+			a, size := utf8.DecodeRuneInString(ansi)
+			if size == 0 || a != r {
+				return false, nil
+			}
+			ansi = ansi[size:]
+			continue
+		}
+		for range utf16Len(r) {
+			_, size := utf8.DecodeRuneInString(ansi)
+			if size == 0 {
+				return false, nil
+			}
+			ansi = ansi[size:]
+		}
+	}
+	return ansi == "", nil
+}
+
+// encodeRune returns the bytes r takes in the encoder's code page, or false if
+// the code page cannot represent it.
+func encodeRune(encoder *encoding.Encoder, r rune) ([]byte, bool) {
+	if r == utf8.RuneError {
+		return nil, false
+	}
+	b, err := encoder.Bytes(utf8.AppendRune(nil, r))
+	if err != nil {
+		return nil, false
+	}
+	return b, true
+}
+
+// utf16Len is how many UTF-16 units, and so default characters, r takes.
+func utf16Len(r rune) int {
+	if r >= 0x10000 {
+		return 2
+	}
+	return 1
+}
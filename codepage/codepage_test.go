@@ -0,0 +1,132 @@
+package codepage
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		cp   uint32
+		b    []byte
+		want string
+	}{
+		{1252, []byte("Caf\xE9 \x80"), "Café €"},
+		{874, []byte{0xDB}, "\uFFFD"},
+		{1251, []byte{0xCF, 0xCA}, "ПК"},
+		{1255, []byte{0xE0, 0xCA}, "אֺ"},
+		{932, []byte{'P', 'C', '-', 0x93, 0xFA, 0x96, 0x7B}, "PC-日本"},
+		{936, []byte{0xD6, 0xD0, 0xCE, 0xC4}, "中文"},
+		{949, []byte{0xC7, 0xD1, 0xB1, 0xB9}, "한국"},
+		{950, []byte{0xA4, 0xA4, 0xA4, 0xE5}, "中文"},
+		{UTF8, []byte("PC-א"), "PC-א"},
+	}
+	for _, tt := range tests {
+		got, err := Decode(tt.cp, tt.b)
+		if err != nil || got != tt.want {
+			t.Errorf("Decode(%d, % X) = %q, %v; want %q", tt.cp, tt.b, got, err, tt.want)
+		}
+	}
+	if _, err := Decode(1361, []byte("a")); err == nil {
+		t.Error("cp1361 decoded without error")
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		cp uint32
+		b  []byte
+		r  rune
+	}{
+		{1252, []byte{0x80}, '€'},
+		{1252, []byte{0xE9}, 'é'},
+		{1250, []byte{0x8A}, 'Š'},
+		{1251, []byte{0xC0}, 'А'},
+		{1253, []byte{0xC1}, 'Α'},
+		{1254, []byte{0xF0}, 'ğ'},
+		{1255, []byte{0xE0}, 'א'},
+		{1256, []byte{0xC7}, 'ا'},
+		{1257, []byte{0xE8}, 'č'},
+		{1258, []byte{0xD0}, 'Đ'},
+		{874, []byte{0xA1}, 'ก'},
+		{932, []byte{0x93, 0xFA}, '日'},
+		{932, []byte{0xB1}, 'ｱ'},
+		{936, []byte{0xD6, 0xD0}, '中'},
+		{949, []byte{0xC7, 0xD1}, '한'},
+		{950, []byte{0xA4, 0xA4}, '中'},
+	}
+	for _, tt := range tests {
+		s, err := Decode(tt.cp, tt.b)
+		if err != nil || s != string(tt.r) {
+			t.Errorf("cp%d: % X decodes to %q, %v; want %U", tt.cp, tt.b, s, err, tt.r)
+		}
+		b, err := Encode(tt.cp, string(tt.r))
+		if err != nil || !bytes.Equal(b, tt.b) {
+			t.Errorf("cp%d: %U encodes to % X, %v; want % X", tt.cp, tt.r, b, err, tt.b)
+		}
+	}
+
+	// Bytes 1252 leaves undefined decode to U+FFFD, which it cannot encode
+	for _, c := range []byte{0x81, 0x8D, 0x8F, 0x90, 0x9D} {
+		s, err := Decode(1252, []byte{c})
+		if err != nil || s != "\uFFFD" {
+			t.Errorf("cp1252: %02X decodes to %q, %v; want U+FFFD", c, s, err)
+		}
+		b, err := Encode(1252, s)
+		if err != nil || string(b) != "?" {
+			t.Errorf("cp1252: %q encodes to % X, %v; want '?'", s, b, err)
+		}
+	}
+}
+
+func TestEncodeUnrepresentable(t *testing.T) {
+	tests := []struct {
+		cp   uint32
+		s    string
+		want string
+	}{
+		// One default character per UTF-16 unit, so the emoji takes two
+		{1252, "aא\U0001F600b", "a???b"},
+		{932, "PC-日א", "PC-\x93\xFA?"},
+	}
+	for _, tt := range tests {
+		got, err := Encode(tt.cp, tt.s)
+		if err != nil || string(got) != tt.want {
+			t.Errorf("Encode(%d, %q) = %q, %v; want %q", tt.cp, tt.s, got, err, tt.want)
+		}
+	}
+	if _, err := Encode(1361, "a"); err == nil {
+		t.Error("cp1361 encoded without error")
+	}
+}
+
+func TestEquivalent(t *testing.T) {
+	tests := []struct {
+		cp         uint32
+		ansi, wide string
+		want       bool
+	}{
+		{1252, "Café", "Café", true},
+		{1252, "Cafe", "Café", false},
+		// Windows may substitute '?' or a best-fit character for what 1252 lacks
+		{1252, "PC-?", "PC-א", true},
+		{1252, "PC-a", "PC-א", true},
+		{1252, "PC-??", "PC-\U0001F600", true},
+		{1252, "PC-?", "PC-\U0001F600", false},
+		{1255, "אֺ", "אֺ", true},
+		{932, "PC-日本", "PC-日本", true},
+		{932, "PC-日?", "PC-日本", false},
+		{932, "PC-日本?", "PC-日本א", true},
+		{936, "中文", "中文", true},
+		{UTF8, "PC-א", "PC-א", true},
+	}
+	for _, tt := range tests {
+		got, err := Equivalent(tt.cp, tt.ansi, tt.wide)
+		if err != nil || got != tt.want {
+			t.Errorf("Equivalent(%d, %q, %q) = %v, %v; want %v", tt.cp, tt.ansi, tt.wide, got, err, tt.want)
+		}
+	}
+	if _, err := Equivalent(1361, "a", "a"); err == nil {
+		t.Error("cp1361 compared without error")
+	}
+}
@@ -7,6 +7,7 @@ require (
 	github.com/seekehr/DevSpoofGO v0.0.0-20250512145500-000000000000
 	github.com/yusufpapurcu/wmi v1.2.4
 	golang.org/x/sys v0.33.0
+	golang.org/x/text v0.32.0
 )
 
 require (
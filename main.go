@@ -1,17 +1,27 @@
+//go:build windows
+
 package main
 
 import (
+	"bytes"
+	"errors"
 	"flag"
 	"fmt"
 	"github.com/fatih/color"
+	"github.com/seekehr/DevSpoofGOTest/acpi"
+	"github.com/seekehr/DevSpoofGOTest/codepage"
 	"github.com/seekehr/DevSpoofGOTest/native"
+	"github.com/seekehr/DevSpoofGOTest/productkey"
+	"github.com/seekehr/DevSpoofGOTest/reg"
+	"github.com/seekehr/DevSpoofGOTest/smbios"
 	"github.com/seekehr/DevSpoofGOTest/wmi"
-	"golang.org/x/sys/windows/registry"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
+	"unicode/utf8"
 )
 
 var (
@@ -30,8 +40,34 @@ func main() {
 	certificatesFlag := flag.Bool("c", false, "enable certificate output")
 	versionInfoFlag := flag.Bool("v", false, "enable version native output")
 	wmiFlag := flag.Bool("w", false, "enable WMI output")
+	memoryFlag := flag.Bool("m", false, "enable memory device output (SMBIOS vs WMI)")
+	oemFlag := flag.Bool("e", false, "enable SMBIOS OEM strings and configuration options output")
+	dmiFlag := flag.Bool("dmi", false, "print the SMBIOS table in dmidecode's text layout")
+	acpiFlag := flag.Bool("a", false, "enable ACPI table output (headers, MSDM key, SLIC marker)")
+	chidFlag := flag.Bool("chid", false, "compute CHIDs from SMBIOS and compare with ComputerHardwareIds")
+	wowFlag := flag.Bool("wow", false, "compare every registry identifier between the 32-bit and 64-bit views")
+	ntFlag := flag.Bool("nt", false, "compare every registry identifier between advapi32 and ntdll reads")
+	surfaceFlag := flag.Bool("surface", false, "read each identifier through every registered API path and print an agreement matrix")
+	smbiosSource := flag.String("smbios", "live", "SMBIOS source: live, sysfs[:dir] or a dump file path")
+	acpiSource := flag.String("acpi", "live", "ACPI source: live or sysfs[:dir]")
+	registrySource := flag.String("registry", "live", "registry source: live, nt, hive:<dir> or a .reg file path")
+	flag.StringVar(&volumePath, "volume", "", "restrict -d volume output to the volume containing this path")
+	flag.StringVar(&dumpDir, "dump", "", "directory to write each changed RSMB buffer to")
 	flag.Parse()
 
+	if err := native.SetSMBIOSSource(*smbiosSource); err != nil {
+		fmt.Println(red(err.Error()))
+		os.Exit(1)
+	}
+	if err := native.SetACPISource(*acpiSource); err != nil {
+		fmt.Println(red(err.Error()))
+		os.Exit(1)
+	}
+	if err := native.SetRegistry(*registrySource); err != nil {
+		fmt.Println(red(err.Error()))
+		os.Exit(1)
+	}
+
 	var activeFlags []string
 	if *osFlag {
 		activeFlags = append(activeFlags, "o")
@@ -54,6 +90,42 @@ func main() {
 	if *wmiFlag {
 		activeFlags = append(activeFlags, "w")
 	}
+	if *memoryFlag {
+		activeFlags = append(activeFlags, "m")
+	}
+	if *oemFlag {
+		activeFlags = append(activeFlags, "e")
+	}
+	if *acpiFlag {
+		activeFlags = append(activeFlags, "a")
+	}
+	if *dmiFlag {
+		activeFlags = append(activeFlags, "dmi")
+	}
+	if *chidFlag {
+		activeFlags = append(activeFlags, "chid")
+	}
+	if *wowFlag {
+		activeFlags = append(activeFlags, "wow")
+	}
+	if *ntFlag {
+		activeFlags = append(activeFlags, "nt")
+	}
+	if *surfaceFlag {
+		activeFlags = append(activeFlags, "surface")
+	}
+	if dumpDir != "" {
+		// A dump of a sysfs or file source would only copy the input back out
+		if _, ok := native.SMBIOSSource.(native.LiveSMBIOSSource); !ok {
+			fmt.Println(red("-dump records what Windows reports and needs -smbios live, not " + native.SMBIOSSource.Name()))
+			os.Exit(1)
+		}
+		if err := os.MkdirAll(dumpDir, 0o755); err != nil {
+			fmt.Println(red("Error creating dump directory: " + err.Error()))
+			os.Exit(1)
+		}
+		activeFlags = append(activeFlags, "dump")
+	}
 
 	i := 0
 	for {
@@ -63,6 +135,21 @@ func main() {
 	}
 }
 
+// volumePath restricts the -d volume list to the volume containing it; empty lists every volume.
+var volumePath string
+
+// dumpDir is where -dump writes RSMB buffers; lastDump is the last buffer written.
+var (
+	dumpDir  string
+	lastDump []byte
+)
+
+// lastSnapshot is the SMBIOS snapshot of the previous iteration that read one.
+var (
+	lastSnapshot          *native.SMBIOSSnapshot
+	lastSnapshotIteration int
+)
+
 var red = color.New(color.FgRed).SprintFunc()
 var green = color.New(color.FgGreen).SprintFunc()
 var blue = color.New(color.FgBlue).SprintFunc()
@@ -82,13 +169,22 @@ func OutputProcess(iteration int, flags ...string) {
 	fmt.Println("=========" + blue("DevSpoofGOTest.exe "+strconv.Itoa(iteration)) + "=========")
 	fmt.Println(green("PID: ") + strconv.Itoa(process))
 
+	var snapshot *native.SMBIOSSnapshot
+	for _, aflag := range flags {
+		if aflag == "h" || aflag == "m" || aflag == "e" || aflag == "dmi" || aflag == "chid" || aflag == "surface" || aflag == "dump" {
+			snapshot = native.TakeSMBIOSSnapshot()
+			outputSMBIOSChanges(iteration, snapshot)
+			break
+		}
+	}
+
 	for _, aflag := range flags {
 		if aflag == "o" {
 			outputOS()
 		} else if aflag == "d" {
 			outputDisk()
 		} else if aflag == "h" {
-			outputHardware()
+			outputHardware(snapshot)
 		} else if aflag == "n" {
 			outputNetwork()
 		} else if aflag == "c" {
@@ -97,6 +193,24 @@ func OutputProcess(iteration int, flags ...string) {
 			outputVersionInfo()
 		} else if aflag == "w" {
 			outputWMI()
+		} else if aflag == "m" {
+			outputMemory(snapshot)
+		} else if aflag == "e" {
+			outputOEMStrings(snapshot)
+		} else if aflag == "a" {
+			outputACPI()
+		} else if aflag == "dmi" {
+			outputDmidecode(snapshot)
+		} else if aflag == "chid" {
+			outputCHIDs(snapshot)
+		} else if aflag == "wow" {
+			outputRegistryComparison("Registry Views (32-bit || 64-bit)", native.CompareRegistryViews())
+		} else if aflag == "nt" {
+			outputRegistryComparison("Registry APIs (advapi32 || ntdll)", native.CompareRegistryAPIs())
+		} else if aflag == "surface" {
+			outputAPISurfaces(snapshot)
+		} else if aflag == "dump" {
+			outputDump(iteration, snapshot)
 		} else {
 			fmt.Println(red("Invalid flag: " + aflag))
 		}
@@ -105,11 +219,44 @@ func OutputProcess(iteration int, flags ...string) {
 	fmt.Println("===========================================\n")
 }
 
+// outputSMBIOSChanges reports which byte ranges of the raw SMBIOS table changed since the
+// previous snapshot, and which structure each range falls in.
+func outputSMBIOSChanges(iteration int, snapshot *native.SMBIOSSnapshot) {
+	previous, previousIteration := lastSnapshot, lastSnapshotIteration
+	if snapshot.Raw != nil {
+		lastSnapshot, lastSnapshotIteration = snapshot, iteration
+	}
+	if previous == nil || snapshot.Raw == nil {
+		return
+	}
+
+	changes := smbios.DiffRaw(previous.Raw, snapshot.Raw)
+	if len(changes) == 0 {
+		return
+	}
+	str := red(fmt.Sprintf("SMBIOS table changed since iteration %d (%d -> %d bytes, %d ranges):", previousIteration, len(previous.Raw), len(snapshot.Raw), len(changes)))
+	for _, change := range changes {
+		str += fmt.Sprintf("\n\t0x%04X-0x%04X (%d bytes)", change.Offset, change.Offset+change.Length-1, change.Length)
+		if snapshot.Table == nil {
+			continue
+		}
+		if s := snapshot.Table.StructureAt(change.Offset); s != nil {
+			str += cyan(fmt.Sprintf(" in type %d, handle 0x%04X", s.Type, s.Handle))
+		}
+	}
+	fmt.Println(str)
+}
+
 func outputOS() {
 	computerNameA, errCompA := native.GetComputerNameA()
 	computerNameW, errCompW := native.GetComputerNameW()
 
-	str := green("PC name: ")
+	str := green("ANSI Code Page: ") + strconv.Itoa(int(native.GetACP()))
+	if !codepage.Supported(native.GetACP()) {
+		str += cyan(" (no built-in encoding, A-variant strings shown as raw bytes)")
+	}
+
+	str += "\n" + green("PC name: ")
 	if errCompA != nil {
 		str += red("Error getting computer name ("+errCompA.Error()+")") + " || "
 	} else {
@@ -120,23 +267,93 @@ func outputOS() {
 	} else {
 		str += computerNameW
 	}
+	if errCompA == nil && errCompW == nil {
+		str += ansiComparison(computerNameA, computerNameW)
+	}
+
+	str += "\n" + green("=====Computer Names=====")
+	names := native.GetComputerNames()
+	for start := 0; start < len(names); start += computerNameColumns {
+		end := min(start+computerNameColumns, len(names))
+		str += "\n" + computerNameRow(names[start:end], computerNameW)
+	}
 	fmt.Println(str)
 }
 
-func outputDisk() {
-	volumeSerialA, errVolA := native.GetVolumeSerialA()
-	volumeSerialW, errVolW := native.GetVolumeSerialW()
+// computerNameColumns is how many sources outputOS prints side by side per row.
+const computerNameColumns = 4
 
-	str := green("Volume Serial: ")
-	if errVolA != nil {
-		str += red("Error getting computer name ("+errVolA.Error()+")") + " || "
-	} else {
-		str += volumeSerialA + cyan(" || ")
+// computerNameRow prints one column per source: its name over its value. Host names that
+// differ from GetComputerNameW (compared case-insensitively on the 15-character NetBIOS
+// prefix, since DNS names may be longer) are shown in red.
+func computerNameRow(names []native.ComputerName, reference string) string {
+	var header, values string
+	for _, name := range names {
+		value := name.Value
+		if name.Err != nil {
+			value = "Error (" + name.Err.Error() + ")"
+		} else if value == "" {
+			value = "Not Specified"
+		}
+		width := max(utf8.RuneCountInString(name.Source), utf8.RuneCountInString(value)) + 2
+		header += green(fmt.Sprintf("%-*s", width, name.Source))
+
+		cell := fmt.Sprintf("%-*s", width, value)
+		switch {
+		case name.Err != nil:
+			values += red(cell)
+		case name.Value == "":
+			values += cyan(cell)
+		case name.Host && !strings.EqualFold(netBIOSPrefix(name.Value), netBIOSPrefix(reference)):
+			values += red(cell)
+		default:
+			values += cell
+		}
+	}
+	return header + "\n" + values
+}
+
+// netBIOSPrefix truncates a host name to the 15 characters NetBIOS keeps.
+func netBIOSPrefix(name string) string {
+	if r := []rune(name); len(r) > 15 {
+		return string(r[:15])
 	}
-	if errVolW != nil {
-		str += red("Error getting computer name (" + errVolW.Error() + ")")
+	return name
+}
+
+func outputDisk() {
+	var volumes []native.Volume
+	var err error
+	if volumePath != "" {
+		var volume native.Volume
+		volume, err = native.GetVolumeForPath(volumePath)
+		if err == nil {
+			volumes = append(volumes, volume)
+		}
 	} else {
-		str += volumeSerialW
+		volumes, err = native.GetVolumes()
+	}
+
+	str := green("=====Volumes (A || W)=====")
+	if err != nil {
+		str += "\n" + red("Error enumerating volumes ("+err.Error()+")")
+	}
+	for _, volume := range volumes {
+		str += "\n" + cyan("====="+volume.Name+"=====")
+		if volume.MountPointsErr != nil {
+			str += "\n" + green("Mount Points: ") + red("Error ("+volume.MountPointsErr.Error()+")")
+		} else {
+			str += field("Mount Points", strings.Join(volume.MountPoints, ", "))
+		}
+		str += volumeQueryFields(volume.Queries[0])
+		// Mount points usually answer exactly like the GUID path; only print the ones that don't
+		for _, q := range volume.Queries[1:] {
+			if q.SameAs(volume.Queries[0]) {
+				str += "\n" + green(q.Root+": ") + "same as volume GUID path"
+				continue
+			}
+			str += "\n" + green(q.Root+": ") + red("differs from volume GUID path") + volumeQueryFields(q)
+		}
 	}
 
 	diskSerial, err := native.GetActiveDriveSerialNumber()
@@ -149,8 +366,8 @@ func outputDisk() {
 	fmt.Println(str)
 }
 
-func outputHardware() {
-	motherboardSerial, err := native.GetMotherboardSerial()
+func outputHardware(snapshot *native.SMBIOSSnapshot) {
+	motherboardSerial, err := snapshot.MotherboardSerial()
 
 	str := green("Motherboard Serial: ")
 	if err != nil {
@@ -159,7 +376,7 @@ func outputHardware() {
 		str += motherboardSerial
 	}
 
-	biosSerial, err := native.GetBIOSSerial()
+	biosSerial, err := snapshot.BIOSSerial()
 	str += "\n" + green("BIOS Serial: ")
 	if err != nil {
 		str += red("Error getting BIOS serial (" + err.Error() + ")")
@@ -167,7 +384,7 @@ func outputHardware() {
 		str += biosSerial
 	}
 
-	processorID, err := native.GetProcessorID()
+	processorID, err := snapshot.ProcessorID()
 	str += "\n" + green("Processor ID: ")
 	if err != nil {
 		str += red("Error getting processor ID (" + err.Error() + ")")
@@ -175,12 +392,23 @@ func outputHardware() {
 		str += processorID
 	}
 
-	systemUUID, err := native.GetSystemUUID()
+	systemUUID, err := snapshot.SystemUUID()
 	str += "\n" + green("System UUID: ")
 	if err != nil {
 		str += red("Error getting system UUID (" + err.Error() + ")")
 	} else {
-		str += systemUUID
+		str += systemUUID.String()
+		switch {
+		case systemUUID.IsNotSet():
+			str += cyan(" (Not Present)")
+		case systemUUID.IsAbsent():
+			str += cyan(" (Not Settable)")
+		default:
+			str += cyan(fmt.Sprintf(" (version %d, %s variant)", systemUUID.Version(), systemUUID.Variant()))
+			if !systemUUID.WellFormed() {
+				str += " " + red("not a well-formed RFC 4122 UUID")
+			}
+		}
 	}
 
 	machineGUID, err := native.GetMachineGUID()
@@ -190,9 +418,146 @@ func outputHardware() {
 	} else {
 		str += machineGUID
 	}
+
+	str += cyan("\n=====BIOS (Type 0)=====")
+	bios, err := snapshot.BIOSInformation()
+	if err != nil {
+		str += "\n" + red("Error getting BIOS information ("+err.Error()+")")
+	} else {
+		str += field("Vendor", bios.Vendor)
+		str += field("Version", bios.Version)
+		str += field("Release Date", bios.ReleaseDate)
+		str += field("Address", fmt.Sprintf("0x%04X0", bios.StartingSegment))
+		str += field("ROM Size", bios.ROMSizeString())
+		if bios.HasReleases {
+			str += field("BIOS Revision", bios.BIOSRevision())
+			str += field("Firmware Revision", bios.FirmwareRevision())
+		}
+		str += "\n" + green("Characteristics: ")
+		for _, c := range bios.CharacteristicNames() {
+			str += "\n\t" + c
+		}
+	}
+
+	str += cyan("\n=====System (Type 1)=====")
+	system, err := snapshot.SystemInformation()
+	if err != nil {
+		str += "\n" + red("Error getting system information ("+err.Error()+")")
+	} else {
+		str += field("Manufacturer", system.Manufacturer)
+		str += field("Product Name", system.ProductName)
+		str += field("Version", system.Version)
+		str += field("Serial Number", system.SerialNumber)
+		if system.HasSKUAndFamily {
+			str += field("SKU Number", system.SKUNumber)
+			str += field("Family", system.Family)
+		}
+		if system.HasWakeUpType {
+			str += field("Wake-up Type", system.WakeUpTypeString())
+		}
+	}
+
+	str += cyan("\n=====SMBIOS Integrity=====")
+	anomalies, err := snapshot.Integrity()
+	if err != nil {
+		str += "\n" + red("Error checking SMBIOS integrity ("+err.Error()+")")
+	} else if len(anomalies) == 0 {
+		str += "\n" + green("No structural anomalies found")
+	}
+	for _, anomaly := range anomalies {
+		str += "\n" + red(anomaly.String())
+	}
+
+	baseboards, err := snapshot.Baseboards()
+	if err != nil {
+		str += "\n" + red("Error getting baseboard information ("+err.Error()+")")
+	}
+	for i, baseboard := range baseboards {
+		str += cyan(fmt.Sprintf("\n=====Baseboard %d (Type 2, handle 0x%04X)=====", i+1, baseboard.Handle))
+		str += field("Manufacturer", baseboard.Manufacturer)
+		str += field("Product Name", baseboard.Product)
+		str += field("Version", baseboard.Version)
+		str += field("Serial Number", baseboard.SerialNumber)
+		str += field("Asset Tag", baseboard.AssetTag)
+		if baseboard.HasBoardType {
+			str += field("Location In Chassis", baseboard.LocationInChassis)
+			str += field("Chassis Handle", fmt.Sprintf("0x%04X", baseboard.ChassisHandle))
+			str += field("Type", baseboard.BoardTypeString())
+		}
+		if baseboard.HasFeatureFlags {
+			str += "\n" + green("Features: ")
+			for _, f := range baseboard.FeatureNames() {
+				str += "\n\t" + f
+			}
+		}
+		str += "\n" + green("Contained Object Handles: ") + strconv.Itoa(len(baseboard.ContainedHandles))
+		for _, h := range baseboard.ContainedHandles {
+			str += fmt.Sprintf("\n\t0x%04X", h)
+		}
+	}
+
+	chassis, err := snapshot.Chassis()
+	if err != nil {
+		str += "\n" + red("Error getting chassis information ("+err.Error()+")")
+	}
+	for i, c := range chassis {
+		str += cyan(fmt.Sprintf("\n=====Chassis %d (Type 3, handle 0x%04X)=====", i+1, c.Handle))
+		str += field("Manufacturer", c.Manufacturer)
+		str += field("Type", c.TypeString())
+		if c.Locked {
+			str += field("Lock", "Present")
+		} else {
+			str += field("Lock", "Not Present")
+		}
+		str += field("Version", c.Version)
+		str += field("Serial Number", c.SerialNumber)
+		str += field("Asset Tag", c.AssetTag)
+		str += field("SKU Number", c.SKUNumber)
+		if c.HasStates {
+			str += field("Boot-up State", c.BootUpStateString())
+			str += field("Power Supply State", c.PowerSupplyStateString())
+			str += field("Thermal State", c.ThermalStateString())
+			str += field("Security Status", c.SecurityStatusString())
+		}
+	}
+
+	processors, err := snapshot.Processors()
+	if err != nil {
+		str += "\n" + red("Error getting processor information ("+err.Error()+")")
+	}
+	for i, p := range processors {
+		str += cyan(fmt.Sprintf("\n=====Processor %d (Type 4, handle 0x%04X)=====", i+1, p.Handle))
+		str += field("Socket Designation", p.SocketDesignation)
+		str += field("Manufacturer", p.Manufacturer)
+		str += field("Version", p.Version)
+		str += field("Serial Number", p.SerialNumber)
+		str += field("Asset Tag", p.AssetTag)
+		str += field("Part Number", p.PartNumber)
+		if !p.Populated {
+			str += "\n" + cyan("Socket is unpopulated")
+			continue
+		}
+		str += field("ID", fmt.Sprintf("%X", p.ProcessorID[:]))
+		str += field("Signature", p.Signature().String())
+		str += field("Flags", strings.Join(p.FeatureNames(), " "))
+		str += field("Core Count", strconv.Itoa(int(p.CoreCount)))
+		str += field("Core Enabled", strconv.Itoa(int(p.CoreEnabled)))
+		str += field("Thread Count", strconv.Itoa(int(p.ThreadCount)))
+		for _, issue := range p.SignatureIssues() {
+			str += "\n" + red("Invalid signature: "+issue)
+		}
+	}
 	fmt.Println(str)
 }
 
+// field renders one "label: value" line, marking empty SMBIOS strings as not specified.
+func field(label, value string) string {
+	if value == "" {
+		return "\n" + green(label+": ") + cyan("Not Specified")
+	}
+	return "\n" + green(label+": ") + value
+}
+
 func outputNetwork() {
 	adapters, err := native.GetWlanInfo()
 
@@ -251,8 +616,7 @@ func outputCertificates() {
 
 func outputVersionInfo() {
 	str := green("=====Version Info=====")
-	regPath := `SOFTWARE\Microsoft\Windows NT\CurrentVersion`
-	k, err := registry.OpenKey(registry.LOCAL_MACHINE, regPath, registry.READ)
+	k, err := native.OpenCurrentVersion()
 	if err != nil {
 		str += red("Error getting version native: " + err.Error())
 	} else {
@@ -263,6 +627,15 @@ func outputVersionInfo() {
 			str += "\n" + green("DigitalProductId: ") + digitalId
 		}
 
+		productKey, err := native.GetProductKey(k)
+		if errors.Is(err, productkey.ErrNoKey) {
+			str += "\n" + green("Product Key: ") + cyan("Not Stored (digital license)")
+		} else if err != nil {
+			str += "\n" + red("Error decoding product key: "+err.Error())
+		} else {
+			str += "\n" + green("Product Key: ") + productKey
+		}
+
 		digitalId4, err := native.GetDigitalID4(k)
 		if err != nil {
 			str += "\n" + red("Error getting DigitalProductId4: "+err.Error())
@@ -291,6 +664,33 @@ func outputVersionInfo() {
 			str += "\n" + green("InstallTime: ") + installTime
 		}
 
+		drift, err := native.GetInstallTimeDrift(k)
+		if err != nil {
+			str += "\n" + red("Error comparing InstallDate and InstallTime: "+err.Error())
+		} else if drift >= time.Second {
+			str += "\n" + green("InstallDate/InstallTime: ") + red("MISMATCH (differ by "+drift.String()+")")
+		} else {
+			str += "\n" + green("InstallDate/InstallTime: ") + "consistent"
+		}
+
+		for _, name := range []string{"BuildGUID", "RegisteredOwner", "RegisteredOrganization", "ProductName", "EditionID", "DisplayVersion", "CurrentBuild"} {
+			value, err := native.GetVersionString(k, name)
+			if err != nil {
+				str += "\n" + red("Error getting "+name+": "+err.Error())
+			} else {
+				str += field(name, value)
+			}
+		}
+
+		ubr, err := native.GetUBR(k)
+		if err != nil {
+			str += "\n" + red("Error getting UBR: "+err.Error())
+		} else {
+			str += "\n" + green("UBR: ") + strconv.FormatUint(ubr, 10)
+		}
+
+		str += digitalProductID4Fields(k)
+
 		defer k.Close()
 	}
 
@@ -298,6 +698,329 @@ func outputVersionInfo() {
 	fmt.Println(str)
 }
 
+// digitalProductID4Fields prints the decoded DigitalProductId4 fields and checks them (EditionID
+// and the product code in the Advanced PID), and the ProductId embedded in DigitalProductId,
+// against the plain ProductId and EditionID values.
+func digitalProductID4Fields(k reg.Key) string {
+	str := "\n" + green("=====DigitalProductId4=====")
+	dpid4, err := native.GetDigitalProductID4(k)
+	if err != nil {
+		return str + "\n" + red("Error decoding DigitalProductId4: "+err.Error())
+	}
+
+	str += field("Size", strconv.Itoa(int(dpid4.Size)))
+	if dpid4.Size != productkey.DigitalProductID4Size {
+		str += " " + red(fmt.Sprintf("expected %d", productkey.DigitalProductID4Size))
+	}
+	str += field("Version", strconv.Itoa(int(dpid4.Version)))
+	str += field("Advanced PID", dpid4.AdvancedPID)
+	str += field("Activation ID", dpid4.ActivationID)
+	str += field("OEM ID", dpid4.OEMID)
+	str += field("Edition Type", dpid4.EditionType)
+	str += field("Is Upgrade", strconv.FormatBool(dpid4.IsUpgrade))
+	str += field("CD Key", fmt.Sprintf("%X", dpid4.CDKey))
+	str += field("CD Key SHA-256", fmt.Sprintf("%X", dpid4.CDKey256Hash))
+	str += field("SHA-256", fmt.Sprintf("%X", dpid4.Hash256))
+	str += field("Key Type", dpid4.KeyType)
+	str += field("EULA", dpid4.EULA)
+
+	editionID, err := native.GetEditionID(k)
+	if err != nil {
+		str += "\n" + red("Error getting EditionID: "+err.Error())
+	} else {
+		str += compareField("Edition ID (DigitalProductId4 || EditionID)", dpid4.EditionID, editionID)
+	}
+
+	productID, err := native.GetProductID(k)
+	if err != nil {
+		return str + "\n" + red("Error getting ProductId: "+err.Error())
+	}
+	if code := productkey.ProductCode(productID); code != "" {
+		str += compareField("Product Code (Advanced PID || ProductId)", dpid4.ProductCode(), code)
+	}
+	embeddedProductID, err := native.GetDigitalProductIDProductID(k)
+	if err != nil {
+		str += "\n" + red("Error getting ProductId from DigitalProductId: "+err.Error())
+	} else {
+		str += compareField("Product ID (DigitalProductId || ProductId)", embeddedProductID, productID)
+	}
+	return str
+}
+
+// outputRegistryComparison prints every registry identifier as read through two backends
+// and flags the ones where they disagree.
+func outputRegistryComparison(title string, comparisons []native.RegistryComparison) {
+	str := green("=====" + title + "=====")
+	for _, c := range comparisons {
+		str += "\n" + green(c.Identifier.String()+": ")
+		if errors.Is(c.Errs[0], reg.ErrNotExist) && errors.Is(c.Errs[1], reg.ErrNotExist) {
+			str += cyan("Not Present")
+			continue
+		}
+		for i := range c.Values {
+			if i > 0 {
+				str += cyan(" || ")
+			}
+			if c.Errs[i] != nil {
+				str += red("Error (" + c.Errs[i].Error() + ")")
+			} else {
+				str += c.Values[i].String()
+			}
+		}
+		if c.Diverges() {
+			str += " " + red("MISMATCH")
+		}
+	}
+	fmt.Println(str)
+}
+
+func outputACPI() {
+	str := green("=====ACPI Tables=====")
+	tables, err := native.GetACPITables()
+	if err != nil {
+		fmt.Println(str + "\n" + red("Error getting ACPI tables: "+err.Error()))
+		return
+	}
+
+	for _, t := range tables {
+		if t.Err != nil && t.Raw == nil {
+			str += "\n" + cyan(t.Signature) + " " + red(t.Err.Error())
+			continue
+		}
+		if !t.HasStandardHeader() {
+			str += "\n" + cyan(t.Signature) + fmt.Sprintf(" %d bytes, no standard header", t.Length)
+			continue
+		}
+		str += "\n" + cyan(t.Signature) + fmt.Sprintf(" rev %d, %d bytes, OEM ID %q, OEM Table ID %q, OEM rev 0x%08X, creator %q rev 0x%08X",
+			t.Revision, t.Length, t.OEMID, t.OEMTableID, t.OEMRevision, t.CreatorID, t.CreatorRevision)
+		if t.Err != nil {
+			str += " " + red(t.Err.Error())
+		} else if !t.ChecksumValid() {
+			str += " " + red("BAD CHECKSUM")
+		}
+
+		switch t.Signature {
+		case "MSDM":
+			msdm, err := acpi.DecodeMSDM(t)
+			if err != nil {
+				str += "\n\t" + red("Error decoding MSDM: "+err.Error())
+			} else {
+				str += "\n\t" + green("OEM Product Key: ") + msdm.ProductKey
+			}
+		case "SLIC":
+			slic, err := acpi.DecodeSLIC(t)
+			if err != nil {
+				str += "\n\t" + red("Error decoding SLIC: "+err.Error())
+			} else {
+				str += "\n\t" + green("SLIC Marker: ") + fmt.Sprintf("version %s, OEM ID %q, OEM Table ID %q, flag %q", slic.Version, slic.OEMID, slic.OEMTableID, slic.WindowsFlag)
+				if slic.OEMID != t.OEMID || slic.OEMTableID != t.OEMTableID {
+					str += " " + red("marker does not match table header")
+				}
+			}
+		}
+	}
+	fmt.Println(str)
+}
+
+// outputDmidecode prints the SMBIOS table exactly as dmidecode would lay it out, with no
+// colouring, so the output can be diffed against a Linux dmidecode capture.
+func outputDmidecode(snapshot *native.SMBIOSSnapshot) {
+	if snapshot.Table == nil {
+		fmt.Println(red("Error reading SMBIOS table: " + snapshot.Err.Error()))
+		return
+	}
+	fmt.Print(smbios.RenderDmidecode(snapshot.Table, smbios.DmidecodeSourceLine(native.SMBIOSSource)))
+}
+
+// outputCHIDs lists the CHIDs computed from the SMBIOS table and marks each one Windows
+// did not record in ComputerHardwareIds, then any recorded ID nothing computed matches.
+func outputCHIDs(snapshot *native.SMBIOSSnapshot) {
+	str := green("=====Computer Hardware IDs=====")
+	chids, err := snapshot.CHIDs()
+	if err != nil {
+		fmt.Println(str + "\n" + red("Error computing CHIDs: "+err.Error()))
+		return
+	}
+
+	stored := make(map[string]bool)
+	ids, err := native.GetComputerHardwareIds()
+	if err != nil {
+		str += "\n" + red("Error getting ComputerHardwareIds: "+err.Error())
+	}
+	for _, id := range ids {
+		stored[strings.ToUpper(strings.Trim(id, "{}"))] = false
+	}
+
+	for _, chid := range chids {
+		str += "\n" + green(chid.Name+": ")
+		if len(chid.Missing) > 0 {
+			str += cyan("Not computed (missing " + strings.Join(chid.Missing, ", ") + ")")
+			continue
+		}
+		id := chid.ID.String()
+		str += "{" + strings.ToLower(id) + "}"
+		if _, ok := stored[id]; ok {
+			stored[id] = true
+		} else if ids != nil {
+			str += " " + red("NOT IN REGISTRY")
+		}
+		str += cyan(" <- " + strings.Join(chid.Fields, "&") + " = " + chid.Input)
+	}
+	for _, id := range ids {
+		if !stored[strings.ToUpper(strings.Trim(id, "{}"))] {
+			str += "\n" + red("Registry CHID "+id+" matches no computed CHID")
+		}
+	}
+	fmt.Println(str)
+}
+
+func outputOEMStrings(snapshot *native.SMBIOSSnapshot) {
+	str := green("=====OEM Strings=====")
+	lists, err := snapshot.OEMStrings()
+	if err != nil {
+		str += "\n" + red("Error getting OEM strings: "+err.Error())
+	} else if len(lists) == 0 {
+		str += "\n" + cyan("No OEM strings or configuration options found")
+	}
+	for _, list := range lists {
+		if list.Type == smbios.TypeOEMStrings {
+			str += cyan(fmt.Sprintf("\nOEM Strings (Type 11, handle 0x%04X):", list.Handle))
+		} else {
+			str += cyan(fmt.Sprintf("\nSystem Configuration Options (Type 12, handle 0x%04X):", list.Handle))
+		}
+		for i, s := range list.Strings {
+			str += "\n" + green(fmt.Sprintf("String %d: ", i+1)) + s
+		}
+	}
+	fmt.Println(str)
+}
+
+// outputMemory lists every SMBIOS Type 17 memory device next to the Win32_PhysicalMemory
+// instance for the same slot, so DIMM spoofing can be checked on both paths.
+func outputMemory(snapshot *native.SMBIOSSnapshot) {
+	str := green("=====Memory Devices=====")
+	devices, err := snapshot.MemoryDevices()
+	if err != nil {
+		fmt.Println(str + "\n" + red("Error getting SMBIOS memory devices: "+err.Error()))
+		return
+	}
+	instances, err := wmi.GetPhysicalMemoryDevices()
+	if err != nil {
+		str += "\n" + red("Error getting Win32_PhysicalMemory: "+err.Error())
+	}
+
+	slots := make([]native.MemorySlot, len(instances))
+	for i, instance := range instances {
+		slots[i] = native.MemorySlot{DeviceLocator: instance.DeviceLocator, BankLabel: instance.BankLabel}
+	}
+	used := make([]bool, len(instances))
+	for _, device := range devices {
+		str += cyan(fmt.Sprintf("\n%s / %s (handle 0x%04X):", device.DeviceLocator, device.BankLocator, device.Handle))
+		if !device.Installed() {
+			str += " " + cyan("No Module Installed")
+			continue
+		}
+		str += field("Type", device.MemoryTypeString()+" "+device.FormFactorString())
+
+		match := native.MatchMemorySlot(device, slots, used)
+		if match < 0 {
+			str += field("Size", device.SizeString())
+			str += field("Speed", fmt.Sprintf("%d MT/s", device.Speed))
+			str += field("Manufacturer", device.Manufacturer)
+			str += field("Serial Number", device.SerialNumber)
+			str += field("Part Number", device.PartNumber)
+			str += "\n" + red("No matching Win32_PhysicalMemory instance")
+			continue
+		}
+		used[match] = true
+		instance := instances[match]
+		str += compareField("Size (MB)", strconv.FormatUint(device.Size>>20, 10), strconv.FormatUint(instance.Capacity>>20, 10))
+		str += compareField("Speed (MT/s)", strconv.FormatUint(uint64(device.Speed), 10), strconv.FormatUint(uint64(instance.Speed), 10))
+		str += compareField("Manufacturer", device.Manufacturer, instance.Manufacturer)
+		str += compareField("Serial Number", device.SerialNumber, instance.SerialNumber)
+		str += compareField("Part Number", device.PartNumber, instance.PartNumber)
+	}
+
+	for i, instance := range instances {
+		if !used[i] {
+			str += "\n" + red(fmt.Sprintf("Win32_PhysicalMemory %s / %s (serial %s) has no SMBIOS memory device", instance.DeviceLocator, instance.BankLabel, instance.SerialNumber))
+		}
+	}
+	fmt.Println(str)
+}
+
+// volumeQueryFields renders one GetVolumeInformationA || GetVolumeInformationW query.
+func volumeQueryFields(q native.VolumeQuery) string {
+	if q.ErrA != nil || q.ErrW != nil {
+		var str string
+		if q.ErrA != nil {
+			str += "\n" + green("GetVolumeInformationA: ") + red(q.ErrA.Error())
+		}
+		if q.ErrW != nil {
+			str += "\n" + green("GetVolumeInformationW: ") + red(q.ErrW.Error())
+		}
+		return str
+	}
+
+	str := "\n" + green("Label: ") + q.A.Label + cyan(" || ") + q.W.Label
+	str += ansiComparison(q.A.Label, q.W.Label)
+	str += compareField("File System", q.A.FileSystem, q.W.FileSystem)
+	str += compareField("Flags", fmt.Sprintf("0x%08X", q.A.Flags), fmt.Sprintf("0x%08X", q.W.Flags))
+	for _, name := range q.W.FlagNames() {
+		str += "\n\t" + name
+	}
+	str += compareField("Max Component Length", strconv.Itoa(int(q.A.MaxComponentLength)), strconv.Itoa(int(q.W.MaxComponentLength)))
+	str += compareField("Serial", q.A.SerialString(), q.W.SerialString())
+	return str
+}
+
+// ansiComparison flags an A-variant string that disagrees with its W-variant
+// counterpart, or notes that the active code page leaves them uncomparable.
+func ansiComparison(ansi, wide string) string {
+	equal, err := native.ANSIEquivalent(ansi, wide)
+	if err != nil {
+		return " " + cyan("(cannot compare A and W: "+err.Error()+")")
+	}
+	if !equal {
+		return " " + red("MISMATCH (A and W disagree)")
+	}
+	return ""
+}
+
+// compareField renders "label: native || wmi", flagging values that differ.
+func compareField(label, nativeValue, wmiValue string) string {
+	str := "\n" + green(label+": ") + nativeValue + cyan(" || ") + wmiValue
+	if strings.TrimSpace(nativeValue) != strings.TrimSpace(wmiValue) {
+		str += " " + red("MISMATCH")
+	}
+	return str
+}
+
+// outputDump writes the snapshot's RSMB buffer to dumpDir as rsmb_<iteration>.bin, skipping
+// buffers identical to the previous dump. The names do not say which buffer is real: the
+// program cannot tell whether DevSpoofGO had already injected when it started.
+func outputDump(iteration int, snapshot *native.SMBIOSSnapshot) {
+	str := green("SMBIOS Dump: ")
+	raw := snapshot.Raw
+	if raw == nil {
+		fmt.Println(str + red("Error reading RSMB table: "+snapshot.Err.Error()))
+		return
+	}
+
+	if lastDump != nil && bytes.Equal(raw, lastDump) {
+		fmt.Println(str + cyan("unchanged since last dump"))
+		return
+	}
+
+	path := filepath.Join(dumpDir, "rsmb_"+strconv.Itoa(iteration)+".bin")
+	if err := smbios.WriteDump(path, raw); err != nil {
+		fmt.Println(str + red(err.Error()))
+		return
+	}
+	lastDump = raw
+	fmt.Println(str + path + cyan(fmt.Sprintf(" (%d bytes, SMBIOS %d.%d)", len(raw), raw[1], raw[2])))
+}
+
 func outputWMI() {
 	str := green("=====WMI=====")
 	biosSerial, err := wmi.GetBIOSSerial()
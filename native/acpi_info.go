@@ -0,0 +1,35 @@
+package native
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/seekehr/DevSpoofGOTest/acpi"
+)
+
+// ACPISource is where GetACPITables reads tables from.
+var ACPISource acpi.Source = LiveACPISource{}
+
+// SetACPISource selects the ACPI source from a CLI spec: "live", "sysfs" or "sysfs:<dir>".
+func SetACPISource(spec string) error {
+	switch {
+	case spec == "" || spec == "live":
+		ACPISource = LiveACPISource{}
+	case spec == "sysfs":
+		ACPISource = acpi.SysfsSource{}
+	case strings.HasPrefix(spec, "sysfs:"):
+		ACPISource = acpi.SysfsSource{Dir: strings.TrimPrefix(spec, "sysfs:")}
+	default:
+		return fmt.Errorf("invalid ACPI source %q", spec)
+	}
+	return nil
+}
+
+// GetACPITables reads every ACPI table from ACPISource.
+func GetACPITables() ([]acpi.Table, error) {
+	tables, err := ACPISource.ReadTables()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ACPISource.Name(), err)
+	}
+	return tables, nil
+}
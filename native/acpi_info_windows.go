@@ -0,0 +1,68 @@
+package native
+
+import (
+	"encoding/binary"
+	"fmt"
+	"unsafe"
+
+	"github.com/seekehr/DevSpoofGOTest/acpi"
+)
+
+const ACPI = 0x41435049
+
+var procEnumSystemFirmwareTables = kernel32.NewProc("EnumSystemFirmwareTables")
+
+// LiveACPISource reads the ACPI tables of the running machine through
+// EnumSystemFirmwareTables and GetSystemFirmwareTable.
+type LiveACPISource struct{}
+
+func (LiveACPISource) Name() string {
+	return "live"
+}
+
+func (LiveACPISource) ReadTables() ([]acpi.Table, error) {
+	size, _, err := procEnumSystemFirmwareTables.Call(uintptr(ACPI), 0, 0)
+	if size == 0 {
+		return nil, fmt.Errorf("failed to get required buffer size for ACPI table list: %w", err)
+	}
+
+	buffer := make([]byte, size)
+	written, _, err := procEnumSystemFirmwareTables.Call(
+		uintptr(ACPI),
+		uintptr(unsafe.Pointer(&buffer[0])),
+		uintptr(size),
+	)
+	if written == 0 {
+		return nil, fmt.Errorf("failed to enumerate ACPI tables: %w", err)
+	}
+
+	// The list is a packed array of 4-byte table signatures. A signature is
+	// listed once per table (e.g. one SSDT entry per SSDT), but
+	// GetSystemFirmwareTable only returns the first table with it, so each
+	// signature is read once rather than showing the same table repeatedly.
+	var tables []acpi.Table
+	seen := make(map[uint32]bool)
+	for offset := 0; offset+4 <= int(written); offset += 4 {
+		tableID := binary.LittleEndian.Uint32(buffer[offset : offset+4])
+		if seen[tableID] {
+			continue
+		}
+		seen[tableID] = true
+		signature := string(buffer[offset : offset+4])
+		// One unreadable table does not stop the rest from being listed
+		raw, err := getFirmwareTable(ACPI, tableID)
+		if err != nil {
+			tables = append(tables, acpi.FailedTable(signature, fmt.Errorf("failed to read ACPI table %s: %w", signature, err)))
+			continue
+		}
+		t, err := acpi.ParseTable(raw)
+		if err != nil {
+			t.Err = err
+			if t.Signature == "" {
+				t.Signature = signature
+			}
+		}
+		tables = append(tables, t)
+	}
+	return tables, nil
+}
@@ -0,0 +1,41 @@
+package native
+
+import (
+	"github.com/seekehr/DevSpoofGOTest/codepage"
+)
+
+var procGetACP = kernel32.NewProc("GetACP")
+
+// GetACP returns the process's active ANSI code page, which the A-variant APIs use.
+func GetACP() uint32 {
+	cp, _, _ := procGetACP.Call()
+	return uint32(cp)
+}
+
+// DecodeANSI decodes bytes returned by an A-variant API with the active code page.
+// Code pages without a built-in encoding fall back to the raw bytes.
+func DecodeANSI(b []byte) string {
+	s, err := codepage.Decode(GetACP(), b)
+	if err != nil {
+		return string(b)
+	}
+	return s
+}
+
+// EncodeANSI encodes s for an A-variant API with the active code page. Code pages
+// without a built-in encoding fall back to the UTF-8 bytes.
+func EncodeANSI(s string) []byte {
+	b, err := codepage.Encode(GetACP(), s)
+	if err != nil {
+		return []byte(s)
+	}
+	return b
+}
+
+// ANSIEquivalent reports whether an A-variant result decoded with DecodeANSI agrees
+// with the W-variant result, allowing for characters the code page cannot hold. It
+// returns codepage.ErrUnsupported when the active code page has no built-in
+// encoding, since the raw bytes DecodeANSI falls back to cannot be compared.
+func ANSIEquivalent(ansi, wide string) (bool, error) {
+	return codepage.Equivalent(GetACP(), ansi, wide)
+}
@@ -8,20 +8,20 @@ import (
 	"log"
 	"strings"
 
-	"golang.org/x/sys/windows/registry"
+	"github.com/seekehr/DevSpoofGOTest/reg"
 )
 
 func GetCertificatesFromRegistry() ([]string, error) {
 	certRegPath := `SOFTWARE\Microsoft\SystemCertificates\ROOT\Certificates`
 	var certSummaries []string
 
-	k, err := registry.OpenKey(registry.LOCAL_MACHINE, certRegPath, registry.READ)
+	k, err := Registry.OpenKey(reg.LocalMachine, certRegPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open registry key %s: %w", certRegPath, err)
 	}
 	defer k.Close()
 
-	subKeyNames, err := k.ReadSubKeyNames(-1)
+	subKeyNames, err := k.SubKeyNames()
 	if err != nil {
 		return nil, fmt.Errorf("failed to read subkey names under %s: %w", certRegPath, err)
 	}
@@ -32,7 +32,7 @@ func GetCertificatesFromRegistry() ([]string, error) {
 	}
 
 	for _, subKeyName := range subKeyNames {
-		subK, err := registry.OpenKey(k, subKeyName, registry.READ)
+		subK, err := k.OpenKey(subKeyName)
 		if err != nil {
 			log.Printf("Warning: Failed to open subkey %s\\%s: %v", certRegPath, subKeyName, err)
 			continue
@@ -43,24 +43,24 @@ func GetCertificatesFromRegistry() ([]string, error) {
 		var foundBlob bool
 
 		// 1. Prioritize reading the "Blob" value
-		certBlob, _, err = subK.GetBinaryValue("Blob")
+		certBlob, err = subK.BinaryValue("Blob")
 		if err == nil {
 			foundBlob = true
-		} else if err != registry.ErrNotExist {
+		} else if err != reg.ErrNotExist {
 			log.Printf("Debug: Could not read 'Blob' value from %s\\%s: %v", certRegPath, subKeyName, err)
 		}
 
 		// 2. If "Blob" not found or error, try to find other binary values
 		if !foundBlob {
-			valNames, _ := subK.ReadValueNames(-1)
+			valNames, _ := subK.ValueNames()
 			for _, valName := range valNames {
-				tempBlob, _, readErr := subK.GetBinaryValue(valName)
+				tempBlob, readErr := subK.BinaryValue(valName)
 				if readErr == nil {
 					certBlob = tempBlob
 					foundBlob = true
 					log.Printf("Info: Found binary value '%s' in %s\\%s, attempting to parse as certificate.\n", valName, certRegPath, subKeyName)
 					break
-				} else if readErr != registry.ErrNotExist {
+				} else if readErr != reg.ErrNotExist {
 					log.Printf("Debug: Could not read '%s' as binary from %s\\%s: %v", valName, certRegPath, subKeyName, readErr)
 				}
 			}
@@ -101,7 +101,7 @@ func GetCertificatesFromRegistry() ([]string, error) {
 				if parseMultiErr == nil && len(parsedCerts) > 0 {
 					cert = parsedCerts[0]
 					if len(parsedCerts) > 1 {
-						log.Printf("Info: Found %d certificates in blob from %s\\%s. Processing the first one.", certRegPath, subKeyName, len(parsedCerts))
+						log.Printf("Info: Found %d certificates in blob from %s\\%s. Processing the first one.", len(parsedCerts), certRegPath, subKeyName)
 					}
 				} else {
 					log.Printf("Warning: Failed to parse X.509 certificate from %s\\%s blob (subkey: %s). Initial error (after offset attempt): %v. Multi-cert error (after offset attempt): %v. Blob Hex: %s",
@@ -3,15 +3,17 @@ package native
 import (
 	"encoding/hex"
 	"fmt"
+	"math"
 	"strconv"
 	"time"
 
-	"golang.org/x/sys/windows/registry"
+	"github.com/seekehr/DevSpoofGOTest/productkey"
+	"github.com/seekehr/DevSpoofGOTest/reg"
 )
 
 // getStringValue is a helper to get a string registry value.
-func getStringValue(k registry.Key, name string) (string, error) {
-	s, _, err := k.GetStringValue(name)
+func getStringValue(k reg.Key, name string) (string, error) {
+	s, err := k.StringValue(name)
 	if err != nil {
 		return "", fmt.Errorf("failed to get string value '%s': %w", name, err)
 	}
@@ -19,8 +21,8 @@ func getStringValue(k registry.Key, name string) (string, error) {
 }
 
 // getBinaryValue is a helper to get a binary registry value, returning its hex representation.
-func getBinaryValue(k registry.Key, name string) (string, error) {
-	b, _, err := k.GetBinaryValue(name)
+func getBinaryValue(k reg.Key, name string) (string, error) {
+	b, err := k.BinaryValue(name)
 	if err != nil {
 		return "", fmt.Errorf("failed to get binary value '%s': %w", name, err)
 	}
@@ -42,22 +44,25 @@ func HexFiletimeToFormattedTime(hexFiletime string) (string, error) {
 		return "", fmt.Errorf("failed to parse hex string '%s' as uint64: %w", hexFiletime, err)
 	}
 
-	// Constant for the difference between FILETIME epoch (1601-01-01 UTC)
-	// and Unix epoch (1970-01-01 UTC) in 100-nanosecond intervals.
-	const filetimeEpochDiff = 116444736000000000
-
-	// Convert 100-nanosecond intervals to nanoseconds since Unix epoch.
-	unixNano := (filetimeUint - filetimeEpochDiff) * 100
+	return filetimeUintToFormattedTime(filetimeUint)
+}
 
-	// Create a time.Time object from nanoseconds since Unix epoch.
-	t := time.Unix(0, int64(unixNano))
+// FiletimeToTime converts a FILETIME (100-nanosecond intervals since 1601-01-01 UTC)
+// to a time.Time. The arithmetic is signed so dates before 1970 do not wrap around.
+func FiletimeToTime(filetime uint64) (time.Time, error) {
+	// Difference between the FILETIME epoch (1601-01-01 UTC) and the Unix epoch
+	// (1970-01-01 UTC) in 100-nanosecond intervals
+	const filetimeEpochDiff = 116444736000000000
 
-	// Format the time into a readable string
-	return t.Format("2006-01-02 15:04:05 UTC"), nil
+	if filetime > math.MaxInt64 {
+		return time.Time{}, fmt.Errorf("FILETIME 0x%X is out of range", filetime)
+	}
+	intervals := int64(filetime) - filetimeEpochDiff
+	return time.Unix(intervals/1e7, intervals%1e7*100).UTC(), nil
 }
 
 // GetDigitalID retrieves the DigitalProductId as a hexadecimal string.
-func GetDigitalID(k registry.Key) (string, error) {
+func GetDigitalID(k reg.Key) (string, error) {
 	digitalProductID, err := getBinaryValue(k, "DigitalProductId")
 	if err != nil {
 		return "", fmt.Errorf("failed to get DigitalProductId: %w", err)
@@ -65,8 +70,17 @@ func GetDigitalID(k registry.Key) (string, error) {
 	return digitalProductID, nil
 }
 
+// GetProductKey decodes the product key embedded in DigitalProductId.
+func GetProductKey(k reg.Key) (string, error) {
+	digitalProductID, err := k.BinaryValue("DigitalProductId")
+	if err != nil {
+		return "", fmt.Errorf("failed to get DigitalProductId: %w", err)
+	}
+	return productkey.Decode(digitalProductID)
+}
+
 // GetDigitalID4 retrieves the DigitalProductId4 as a hexadecimal string.
-func GetDigitalID4(k registry.Key) (string, error) {
+func GetDigitalID4(k reg.Key) (string, error) {
 	digitalProductID4, err := getBinaryValue(k, "DigitalProductId4")
 	if err != nil {
 		return "", fmt.Errorf("failed to get DigitalProductId4: %w", err)
@@ -74,8 +88,35 @@ func GetDigitalID4(k registry.Key) (string, error) {
 	return digitalProductID4, nil
 }
 
+// GetDigitalProductID4 decodes the DigitalProductId4 structure.
+func GetDigitalProductID4(k reg.Key) (productkey.DigitalProductID4, error) {
+	digitalProductID4, err := k.BinaryValue("DigitalProductId4")
+	if err != nil {
+		return productkey.DigitalProductID4{}, fmt.Errorf("failed to get DigitalProductId4: %w", err)
+	}
+	return productkey.DecodeDigitalProductID4(digitalProductID4)
+}
+
+// GetDigitalProductIDProductID returns the ProductId embedded in DigitalProductId.
+func GetDigitalProductIDProductID(k reg.Key) (string, error) {
+	digitalProductID, err := k.BinaryValue("DigitalProductId")
+	if err != nil {
+		return "", fmt.Errorf("failed to get DigitalProductId: %w", err)
+	}
+	return productkey.ProductID(digitalProductID)
+}
+
+// GetEditionID retrieves the EditionID as a string.
+func GetEditionID(k reg.Key) (string, error) {
+	editionID, err := getStringValue(k, "EditionID")
+	if err != nil {
+		return "", fmt.Errorf("failed to get EditionID: %w", err)
+	}
+	return editionID, nil
+}
+
 // GetProductID retrieves the ProductId as a string.
-func GetProductID(k registry.Key) (string, error) {
+func GetProductID(k reg.Key) (string, error) {
 	productID, err := getStringValue(k, "ProductId")
 	if err != nil {
 		return "", fmt.Errorf("failed to get ProductId: %w", err)
@@ -84,8 +125,8 @@ func GetProductID(k registry.Key) (string, error) {
 }
 
 // GetInstallDate retrieves the InstallDate (Unix timestamp) and formats it as a string.
-func GetInstallDate(k registry.Key) (string, error) {
-	val, _, err := k.GetIntegerValue("InstallDate")
+func GetInstallDate(k reg.Key) (string, error) {
+	val, err := k.IntegerValue("InstallDate")
 	if err != nil {
 		return "", fmt.Errorf("failed to get InstallDate: %w", err)
 	}
@@ -95,8 +136,8 @@ func GetInstallDate(k registry.Key) (string, error) {
 }
 
 // GetInstallTime "InstallTime" is stored as a REG_QWORD (64-bit integer)
-func GetInstallTime(k registry.Key) (string, error) {
-	installTimeUint, _, err := k.GetIntegerValue("InstallTime")
+func GetInstallTime(k reg.Key) (string, error) {
+	installTimeUint, err := k.IntegerValue("InstallTime")
 	if err != nil {
 		return "", fmt.Errorf("failed to retrieve 'InstallTime' as QWORD: %w", err)
 	}
@@ -110,12 +151,47 @@ func GetInstallTime(k registry.Key) (string, error) {
 }
 
 func filetimeUintToFormattedTime(filetimeUint uint64) (string, error) {
-	const filetimeEpochDiff = 116444736000000000
+	t, err := FiletimeToTime(filetimeUint)
+	if err != nil {
+		return "", err
+	}
+	return t.Format("2006-01-02 15:04:05 UTC"), nil
+}
 
-	// Convert 100-nanosecond intervals (FILETIME) to nanoseconds since Unix epoch.
-	unixNano := (filetimeUint - filetimeEpochDiff) * 100
+// GetVersionString retrieves a string value of the CurrentVersion key, e.g. BuildGUID or DisplayVersion.
+func GetVersionString(k reg.Key, name string) (string, error) {
+	return getStringValue(k, name)
+}
 
-	t := time.Unix(0, int64(unixNano))
+// GetUBR retrieves the update build revision, the number after the build in e.g. 19045.3803.
+func GetUBR(k reg.Key) (uint64, error) {
+	ubr, err := k.IntegerValue("UBR")
+	if err != nil {
+		return 0, fmt.Errorf("failed to get UBR: %w", err)
+	}
+	return ubr, nil
+}
 
-	return t.Format("2006-01-02 15:04:05 UTC"), nil
+// GetInstallTimeDrift returns how far InstallTime (FILETIME) is from InstallDate (Unix
+// seconds), in whole seconds. Windows writes both at setup, so any drift means one was changed.
+func GetInstallTimeDrift(k reg.Key) (time.Duration, error) {
+	installDate, err := k.IntegerValue("InstallDate")
+	if err != nil {
+		return 0, fmt.Errorf("failed to get InstallDate: %w", err)
+	}
+	installTimeUint, err := k.IntegerValue("InstallTime")
+	if err != nil {
+		return 0, fmt.Errorf("failed to retrieve 'InstallTime' as QWORD: %w", err)
+	}
+
+	installTime, err := FiletimeToTime(installTimeUint)
+	if err != nil {
+		return 0, fmt.Errorf("failed to decode 'InstallTime' FILETIME: %w", err)
+	}
+	// InstallDate has whole-second precision, so InstallTime is compared with its fraction dropped
+	drift := installTime.Truncate(time.Second).Sub(time.Unix(int64(installDate), 0))
+	if drift < 0 {
+		drift = -drift
+	}
+	return drift, nil
 }
@@ -71,73 +71,188 @@ func getActiveVol() (string, error) {
 			drive += `\`
 		}
 	}
-	fmt.Println("Drive: " + drive)
 	return drive, nil
 }
 
-// GetVolumeSerialA retrieves the volume serial number using GetVolumeInformationA,
-// requesting only the serial number.
+// GetVolumeInformationA queries root through GetVolumeInformationA. The root path is
+// passed and the label and file system name are returned in the active code page.
+func GetVolumeInformationA(root string) (VolumeInformation, error) {
+	rootPtr, err := syscall.BytePtrFromString(string(EncodeANSI(root)))
+	if err != nil {
+		return VolumeInformation{}, fmt.Errorf("failed to convert root path to byte pointer: %w", err)
+	}
+
+	var info VolumeInformation
+	label := make([]byte, windows.MAX_PATH+1)
+	fileSystem := make([]byte, windows.MAX_PATH+1)
+	ret, _, err := syscall.SyscallN(
+		getVolumeSerialA.Addr(),
+		uintptr(unsafe.Pointer(rootPtr)),                  // lpRootPathName
+		uintptr(unsafe.Pointer(&label[0])),                // lpVolumeNameBuffer
+		uintptr(len(label)),                               // nVolumeNameSize
+		uintptr(unsafe.Pointer(&info.Serial)),             // lpVolumeSerialNumber
+		uintptr(unsafe.Pointer(&info.MaxComponentLength)), // lpMaximumComponentLength
+		uintptr(unsafe.Pointer(&info.Flags)),              // lpFileSystemFlags
+		uintptr(unsafe.Pointer(&fileSystem[0])),           // lpFileSystemNameBuffer
+		uintptr(len(fileSystem)),                          // nFileSystemNameSize
+	)
+	if ret == 0 {
+		return VolumeInformation{}, fmt.Errorf("GetVolumeInformationA(%s) failed: %w", root, err)
+	}
+
+	info.Label = DecodeANSI([]byte(bytePointerToString(&label[0])))
+	info.FileSystem = DecodeANSI([]byte(bytePointerToString(&fileSystem[0])))
+	return info, nil
+}
+
+// GetVolumeInformationW queries root through GetVolumeInformationW.
+func GetVolumeInformationW(root string) (VolumeInformation, error) {
+	rootPtr, err := syscall.UTF16PtrFromString(root)
+	if err != nil {
+		return VolumeInformation{}, fmt.Errorf("failed to convert root path to UTF16 pointer: %w", err)
+	}
+
+	var info VolumeInformation
+	var label, fileSystem [windows.MAX_PATH + 1]uint16
+	ret, _, err := syscall.SyscallN(
+		getVolumeSerialW.Addr(),
+		uintptr(unsafe.Pointer(rootPtr)),                  // lpRootPathName
+		uintptr(unsafe.Pointer(&label[0])),                // lpVolumeNameBuffer
+		uintptr(len(label)),                               // nVolumeNameSize
+		uintptr(unsafe.Pointer(&info.Serial)),             // lpVolumeSerialNumber
+		uintptr(unsafe.Pointer(&info.MaxComponentLength)), // lpMaximumComponentLength
+		uintptr(unsafe.Pointer(&info.Flags)),              // lpFileSystemFlags
+		uintptr(unsafe.Pointer(&fileSystem[0])),           // lpFileSystemNameBuffer
+		uintptr(len(fileSystem)),                          // nFileSystemNameSize
+	)
+	if ret == 0 {
+		return VolumeInformation{}, fmt.Errorf("GetVolumeInformationW(%s) failed: %w", root, err)
+	}
+
+	info.Label = syscall.UTF16ToString(label[:])
+	info.FileSystem = syscall.UTF16ToString(fileSystem[:])
+	return info, nil
+}
+
+// GetVolumeSerialA returns the serial of the working directory's volume through
+// GetVolumeInformationA.
 func GetVolumeSerialA() (string, error) {
 	drive, err := getActiveVol()
 	if err != nil {
 		return "", fmt.Errorf("failed to get active volume: %w", err)
 	}
-	drivePtr, err := syscall.BytePtrFromString(drive)
+	info, err := GetVolumeInformationA(drive)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%X", info.Serial), nil
+}
+
+// GetVolumeSerialW returns the serial of the working directory's volume through
+// GetVolumeInformationW.
+func GetVolumeSerialW() (string, error) {
+	drive, err := getActiveVol()
+	if err != nil {
+		return "", fmt.Errorf("failed to get active volume: %w", err)
+	}
+	info, err := GetVolumeInformationW(drive)
 	if err != nil {
-		return "", fmt.Errorf("failed to convert drive string to byte pointer: %w", err)
+		return "", err
 	}
+	return fmt.Sprintf("%X", info.Serial), nil
+}
 
-	var volumeSerial uint32
+// queryVolume queries root through both the A and W variants.
+func queryVolume(root string) VolumeQuery {
+	q := VolumeQuery{Root: root}
+	q.A, q.ErrA = GetVolumeInformationA(root)
+	q.W, q.ErrW = GetVolumeInformationW(root)
+	return q
+}
 
-	ret, _, _ := syscall.SyscallN(
-		getVolumeSerialA.Addr(),
-		uintptr(unsafe.Pointer(drivePtr)),      // lpRootPathName
-		uintptr(0),                             // lpVolumeNameBuffer (NULL)
-		uintptr(0),                             // nVolumeNameSize (0)
-		uintptr(unsafe.Pointer(&volumeSerial)), // lpVolumeSerialNumber
-		uintptr(0),                             // lpMaximumComponentLength (NULL)
-		uintptr(0),                             // lpFileSystemFlags (NULL)
-		uintptr(0),                             // lpFileSystemNameBuffer (NULL)
-		uintptr(0),                             // nFileSystemNameSize (0)
-	)
+// getVolumeMountPoints lists every drive letter and folder the volume name is mounted at.
+func getVolumeMountPoints(name string) ([]string, error) {
+	namePtr, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert volume name to UTF16 pointer: %w", err)
+	}
 
-	if ret == 0 {
-		return "", fmt.Errorf("GetVolumeInformationA failed: %v", syscall.GetLastError())
+	buf := make([]uint16, windows.MAX_PATH+1)
+	for {
+		var length uint32
+		err = windows.GetVolumePathNamesForVolumeName(namePtr, &buf[0], uint32(len(buf)), &length)
+		if err == nil {
+			break
+		}
+		if err != windows.ERROR_MORE_DATA {
+			return nil, fmt.Errorf("GetVolumePathNamesForVolumeNameW(%s) failed: %w", name, err)
+		}
+		buf = make([]uint16, length)
 	}
 
-	// Format the volume serial number as a hexadecimal string.
-	return fmt.Sprintf("%X", volumeSerial), nil
+	// The names come back as a double-null-terminated list
+	var mountPoints []string
+	for start, i := 0, 0; i < len(buf); i++ {
+		if buf[i] != 0 {
+			continue
+		}
+		if i == start {
+			break
+		}
+		mountPoints = append(mountPoints, syscall.UTF16ToString(buf[start:i]))
+		start = i + 1
+	}
+	return mountPoints, nil
 }
 
-func GetVolumeSerialW() (string, error) {
-	drive := "C:\\"
-	// Use UTF16PtrFromString to get a pointer to a null-terminated UTF-16 string.
-	drivePtr, err := syscall.UTF16PtrFromString(drive)
+// getVolume queries the volume GUID path name and every path it is mounted at.
+func getVolume(name string) Volume {
+	volume := Volume{Name: name, Queries: []VolumeQuery{queryVolume(name)}}
+	volume.MountPoints, volume.MountPointsErr = getVolumeMountPoints(name)
+	for _, mountPoint := range volume.MountPoints {
+		volume.Queries = append(volume.Queries, queryVolume(mountPoint))
+	}
+	return volume
+}
+
+// GetVolumes enumerates every volume with FindFirstVolumeW/FindNextVolumeW, including
+// ones that are not mounted anywhere.
+func GetVolumes() ([]Volume, error) {
+	var buf [windows.MAX_PATH + 1]uint16
+	handle, err := windows.FindFirstVolume(&buf[0], uint32(len(buf)))
 	if err != nil {
-		return "", fmt.Errorf("failed to convert drive string to UTF16 pointer: %w", err)
-	}
-
-	var volumeSerial uint32
-	ret, _, _ := syscall.SyscallN(
-		getVolumeSerialW.Addr(),                // Address of GetVolumeInformationW
-		uintptr(unsafe.Pointer(drivePtr)),      // lpRootPathName (UTF-16 pointer)
-		uintptr(0),                             // lpVolumeNameBuffer (NULL)
-		uintptr(0),                             // nVolumeNameSize (0)
-		uintptr(unsafe.Pointer(&volumeSerial)), // lpVolumeSerialNumber
-		uintptr(0),                             // lpMaximumComponentLength (NULL)
-		uintptr(0),                             // lpFileSystemFlags (NULL)
-		uintptr(0),                             // lpFileSystemNameBuffer (NULL)
-		uintptr(0),                             // nFileSystemNameSize (0)
-	)
+		return nil, fmt.Errorf("FindFirstVolumeW failed: %w", err)
+	}
+	defer windows.FindVolumeClose(handle)
+
+	var volumes []Volume
+	for {
+		volumes = append(volumes, getVolume(syscall.UTF16ToString(buf[:])))
+		err = windows.FindNextVolume(handle, &buf[0], uint32(len(buf)))
+		if err == windows.ERROR_NO_MORE_FILES {
+			return volumes, nil
+		}
+		if err != nil {
+			return volumes, fmt.Errorf("FindNextVolumeW failed: %w", err)
+		}
+	}
+}
 
-	// GetVolumeInformationW returns non-zero on success.
-	if ret == 0 {
-		// Use GetLastError for the specific Windows API error code.
-		return "", fmt.Errorf("GetVolumeInformationW failed: %v", syscall.GetLastError())
+// GetVolumeForPath returns the volume path is on.
+func GetVolumeForPath(path string) (Volume, error) {
+	pathPtr, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return Volume{}, fmt.Errorf("failed to convert path to UTF16 pointer: %w", err)
 	}
 
-	// Format the volume serial number as a hexadecimal string.
-	return fmt.Sprintf("%X", volumeSerial), nil
+	var root, name [windows.MAX_PATH + 1]uint16
+	if err := windows.GetVolumePathName(pathPtr, &root[0], uint32(len(root))); err != nil {
+		return Volume{}, fmt.Errorf("GetVolumePathNameW(%s) failed: %w", path, err)
+	}
+	if err := windows.GetVolumeNameForVolumeMountPoint(&root[0], &name[0], uint32(len(name))); err != nil {
+		return Volume{}, fmt.Errorf("GetVolumeNameForVolumeMountPointW(%s) failed: %w", syscall.UTF16ToString(root[:]), err)
+	}
+	return getVolume(syscall.UTF16ToString(name[:])), nil
 }
 
 func getDiskSerialNumberForPath(drivePath string) (string, error) {
@@ -1,367 +1,268 @@
 package native
 
 import (
-	"bytes"
+	"errors"
 	"fmt"
 	"github.com/seekehr/DevSpoofGO/logger"
-	"golang.org/x/sys/windows/registry"
-	"syscall"
-	"unsafe"
+	"github.com/seekehr/DevSpoofGOTest/reg"
+	"github.com/seekehr/DevSpoofGOTest/smbios"
+	"os"
+	"strings"
 )
 
-// Windows API constants
-const (
-	RSMB                           = 0x52534D42
-	SmbiosTypeSystemInformation    = 1
-	SmbiosTypeBaseboard            = 2 // Type 2 is for baseboard (motherboard) native
-	SmbiosTypeProcessorInformation = 4 // Type 0 is for BIOS native
-)
-
-type RawSMBIOSData struct {
-	Used20CallingMethod byte
-	MajorVersion        byte
-	MinorVersion        byte
-	DmiRevision         byte
-	Length              uint32
-	SMBIOSTableData     [1]byte // This is actually a variable length array
+// SMBIOSSource is where every SMBIOS getter reads its table from.
+var SMBIOSSource smbios.Source = LiveSMBIOSSource{}
+
+// SetSMBIOSSource selects the SMBIOS source from a CLI spec: "live", "sysfs",
+// "sysfs:<dir>" or the path of a RawSMBIOSData dump file.
+func SetSMBIOSSource(spec string) error {
+	switch {
+	case spec == "" || spec == "live":
+		SMBIOSSource = LiveSMBIOSSource{}
+	case spec == "sysfs":
+		SMBIOSSource = smbios.SysfsSource{}
+	case strings.HasPrefix(spec, "sysfs:"):
+		SMBIOSSource = smbios.SysfsSource{Dir: strings.TrimPrefix(spec, "sysfs:")}
+	default:
+		if _, err := os.Stat(spec); err != nil {
+			return fmt.Errorf("invalid SMBIOS source %q: %w", spec, err)
+		}
+		SMBIOSSource = smbios.FileSource{Path: spec}
+	}
+	return nil
 }
 
-type SMBIOSHeader struct {
-	Type   byte
-	Length byte
-	Handle uint16
+// SMBIOSSnapshot is a single read of the SMBIOS table. Every SMBIOS value printed in one
+// iteration is parsed from the same snapshot, so the table is fetched only once.
+type SMBIOSSnapshot struct {
+	Raw   []byte
+	Table *smbios.Table
+	// Err is set when the table could not be read or parsed; Raw is kept
+	// whenever the read itself succeeded.
+	Err error
 }
 
-type SmbiosProcessorInformation struct {
-	SMBIOSHeader
-	SocketDesignation byte // String index
-	ProcessorType     byte
-	Family            byte
-	Manufacturer      byte    // String index
-	ProcessorID       [8]byte // Interpretation is architecture-dependent
-	// ... other fields follow
+// TakeSMBIOSSnapshot reads the table from SMBIOSSource once and parses it.
+func TakeSMBIOSSnapshot() *SMBIOSSnapshot {
+	raw, err := SMBIOSSource.ReadRaw()
+	if err != nil {
+		return &SMBIOSSnapshot{Err: fmt.Errorf("%s: %w", SMBIOSSource.Name(), err)}
+	}
+	table, err := smbios.ParseRawSMBIOSData(raw)
+	return &SMBIOSSnapshot{Raw: raw, Table: table, Err: err}
 }
 
-var (
-	kernel32                   = syscall.NewLazyDLL("kernel32.dll")
-	procGetSystemFirmwareTable = kernel32.NewProc("GetSystemFirmwareTable")
-)
-
-func GetMotherboardSerial() (string, error) {
-	// First call with 0 buffer size to get required size
-	size, _, _ := procGetSystemFirmwareTable.Call(
-		uintptr(RSMB),
-		0,
-		0,
-		0,
-	)
-
-	if size == 0 {
-		return "", fmt.Errorf("failed to get required buffer size")
+func (snap *SMBIOSSnapshot) table() (*smbios.Table, error) {
+	if snap.Table == nil {
+		return nil, snap.Err
 	}
+	return snap.Table, nil
+}
 
-	buffer := make([]byte, size)
-
-	// Second call to get the actual data
-	result, _, _ := procGetSystemFirmwareTable.Call(
-		uintptr(RSMB),
-		0,
-		uintptr(unsafe.Pointer(&buffer[0])),
-		uintptr(size),
-	)
-
-	if result == 0 {
-		return "", fmt.Errorf("failed to get firmware table data")
+func (snap *SMBIOSSnapshot) MotherboardSerial() (string, error) {
+	table, err := snap.table()
+	if err != nil {
+		return "", err
 	}
 
-	// Parse the SMBIOS data to find type 2 (baseboard) structure
-	smbiosData := (*RawSMBIOSData)(unsafe.Pointer(&buffer[0]))
-	// The actual SMBIOS table data starts at SMBIOSTableData
-	tableData := buffer[unsafe.Offsetof(smbiosData.SMBIOSTableData):]
-	// Limit to actual data length reported in the header
-	tableData = tableData[:smbiosData.Length]
+	baseboard := table.First(smbios.TypeBaseboard)
+	if baseboard == nil {
+		return "", fmt.Errorf("baseboard information not found")
+	}
 
-	return parseBaseboardSerial(tableData)
+	// Serial number string index is at offset 0x07 of the baseboard structure
+	serial, err := baseboard.GetString(0x07)
+	if errors.Is(err, smbios.ErrNoString) {
+		return "", fmt.Errorf("no serial number available")
+	}
+	return serial, err
 }
 
-func parseBaseboardSerial(data []byte) (string, error) {
-	offset := 0
-	for offset < len(data) {
-		// Ensure there's enough data for the header
-		if offset+4 > len(data) {
-			break
-		}
-
-		header := (*SMBIOSHeader)(unsafe.Pointer(&data[offset]))
-		// Check if this is a baseboard (type 2) structure
-		if header.Type == SmbiosTypeBaseboard {
-			// The serial number field is typically at offset 0x07 in the fixed part
-			// of the baseboard structure (after the header)
-			if offset+int(header.Length) > len(data) {
-				return "", fmt.Errorf("invalid baseboard structure length")
-			}
-
-			// Get the serial number string index (assumes it's at offset 0x07)
-			serialIndex := data[offset+0x07] // header (4 bytes) + offset to serial
-
-			if serialIndex == 0 {
-				return "", fmt.Errorf("no serial number available")
-			}
-
-			// Find the string section (starts after the fixed structure)
-			stringOffset := offset + int(header.Length)
-
-			// Extract the Nth string (serialIndex)
-			return extractString(data, stringOffset, int(serialIndex))
-		}
-
-		stringOffset := offset + int(header.Length)
-		for stringOffset < len(data)-1 {
-			// Check for double null terminator
-			if data[stringOffset] == 0 && data[stringOffset+1] == 0 {
-				stringOffset += 2
-				break
-			}
-			stringOffset++
-		}
-
-		offset = stringOffset
+func (snap *SMBIOSSnapshot) BIOSSerial() (string, error) {
+	table, err := snap.table()
+	if err != nil {
+		return "", err
+	}
 
-		// If we're at the end of the table or hit invalid data, break
-		if offset >= len(data) || data[offset] == 0 {
-			break
-		}
+	systemInfo := table.First(smbios.TypeSystemInformation)
+	if systemInfo == nil {
+		return "", fmt.Errorf("system information structure (Type 1) not found")
 	}
 
-	return "", fmt.Errorf("baseboard information not found")
+	// Serial number string index is at offset 0x07 of the System Information structure
+	serial, err := systemInfo.GetString(0x07)
+	if errors.Is(err, smbios.ErrNoString) {
+		return "", fmt.Errorf("no serial number available in System Information structure")
+	}
+	return serial, err
 }
 
-func GetBIOSSerial() (string, error) {
-	// First call with 0 buffer size to get required size
-	size, _, _ := procGetSystemFirmwareTable.Call(
-		uintptr(RSMB),
-		0,
-		0,
-		0,
-	)
-
-	if size == 0 {
-		return "", fmt.Errorf("failed to get required buffer size")
+func (snap *SMBIOSSnapshot) ProcessorID() (string, error) {
+	table, err := snap.table()
+	if err != nil {
+		return "", err
 	}
 
-	buffer := make([]byte, size)
-
-	// Second call to get the actual data
-	result, _, _ := procGetSystemFirmwareTable.Call(
-		uintptr(RSMB),
-		0,
-		uintptr(unsafe.Pointer(&buffer[0])),
-		uintptr(size),
-	)
-
-	if result == 0 {
-		return "", fmt.Errorf("failed to get firmware table data")
+	processor := table.First(smbios.TypeProcessorInformation)
+	if processor == nil {
+		return "", fmt.Errorf("processor information structure (Type 4) not found")
 	}
 
-	// Parse the SMBIOS data to find type 1 (system information) structure
-	smbiosData := (*RawSMBIOSData)(unsafe.Pointer(&buffer[0]))
-	// The actual SMBIOS table data starts at SMBIOSTableData
-	tableData := buffer[unsafe.Offsetof(smbiosData.SMBIOSTableData):]
-	// Limit to actual data length reported in the header
-	tableData = tableData[:smbiosData.Length]
+	// ProcessorID is 8 bytes at offset 0x08; its interpretation is architecture-dependent
+	processorID, ok := processor.Bytes(0x08, 8)
+	if !ok {
+		return "", fmt.Errorf("processor information structure (Type 4) too short (%d bytes) to contain ProcessorID (requires at least 16)", processor.Length)
+	}
 
-	return parseBIOSSerial(tableData) // Call the modified parsing function
+	// Format ProcessorID bytes as a hexadecimal string
+	return fmt.Sprintf("%x", processorID), nil
 }
 
-func parseBIOSSerial(data []byte) (string, error) {
-	offset := 0
-	for offset < len(data) {
-		// Ensure there's enough data for the header
-		if offset+4 > len(data) {
-			break
-		}
-
-		header := (*SMBIOSHeader)(unsafe.Pointer(&data[offset]))
-		// type 1 ;3
-		if header.Type == SmbiosTypeSystemInformation {
-			// The serial number field is typically at offset 0x07 in the fixed part
-			// of the System Information structure (after the header)
-			// According to the SMBIOS spec, Type 1 length is at least 0x19 for versions >= 2.4
-			// and the Serial Number is at offset 0x07 from the start of the formatted area.
-			if offset+int(header.Length) > len(data) {
-				return "", fmt.Errorf("invalid system information structure length")
-			}
-
-			// Get the serial number string index (assumes it's at offset 0x07 relative to structure start)
-			// Offset 0x07 from the start of the structure is correct for the Serial Number field in Type 1.
-			serialIndex := data[offset+0x07]
-
-			if serialIndex == 0 {
-				return "", fmt.Errorf("no serial number available in System Information structure")
-			}
-
-			// Find the string section (starts after the fixed structure)
-			stringOffset := offset + int(header.Length)
-
-			// Extract the Nth string (serialIndex)
-			return extractString(data, stringOffset, int(serialIndex))
-		}
-
-		// Move to the next structure
-		// The end of a structure (including its strings) is marked by a double null terminator (0x00 0x00)
-		stringOffset := offset + int(header.Length)
-		for stringOffset < len(data)-1 {
-			// Check for double null terminator
-			if data[stringOffset] == 0 && data[stringOffset+1] == 0 {
-				stringOffset += 2
-				break
-			}
-			stringOffset++
-		}
-
-		if stringOffset >= len(data)-1 && !(data[len(data)-2] == 0 && data[len(data)-1] == 0) {
-			break
-		}
+// BIOSInformation decodes the Type 0 (BIOS Information) structure.
+func (snap *SMBIOSSnapshot) BIOSInformation() (smbios.BIOSInformation, error) {
+	table, err := snap.table()
+	if err != nil {
+		return smbios.BIOSInformation{}, err
+	}
 
-		offset = stringOffset
+	bios := table.First(smbios.TypeBIOSInformation)
+	if bios == nil {
+		return smbios.BIOSInformation{}, fmt.Errorf("BIOS information structure (Type 0) not found")
+	}
+	return smbios.DecodeBIOSInformation(bios)
+}
 
-		if offset >= len(data) || (offset > 0 && data[offset-1] == 0 && data[offset-2] == 0 && data[offset] == 0) {
-			break
-		}
+// SystemInformation decodes the Type 1 (System Information) structure.
+func (snap *SMBIOSSnapshot) SystemInformation() (smbios.SystemInformation, error) {
+	table, err := snap.table()
+	if err != nil {
+		return smbios.SystemInformation{}, err
 	}
 
-	return "", fmt.Errorf("system information structure (Type 1) not found")
+	systemInfo := table.First(smbios.TypeSystemInformation)
+	if systemInfo == nil {
+		return smbios.SystemInformation{}, fmt.Errorf("system information structure (Type 1) not found")
+	}
+	return smbios.DecodeSystemInformation(systemInfo)
 }
 
-func GetProcessorID() (string, error) {
-	// First call with 0 buffer size to get required size
-	size, _, err := procGetSystemFirmwareTable.Call(
-		uintptr(RSMB),
-		0,
-		0,
-		0,
-	)
+// Baseboards decodes every Type 2 (Baseboard) structure.
+func (snap *SMBIOSSnapshot) Baseboards() ([]smbios.BaseboardInformation, error) {
+	table, err := snap.table()
+	if err != nil {
+		return nil, err
+	}
 
-	if size == 0 {
+	var baseboards []smbios.BaseboardInformation
+	for _, s := range table.All(smbios.TypeBaseboard) {
+		baseboard, err := smbios.DecodeBaseboardInformation(s)
 		if err != nil {
-			return "", fmt.Errorf("failed to get required buffer size for SMBIOS data: %w", err)
+			return nil, err
 		}
-		return "", fmt.Errorf("failed to get required buffer size for SMBIOS data: size is 0")
+		baseboards = append(baseboards, baseboard)
 	}
+	return baseboards, nil
+}
 
-	buffer := make([]byte, size)
-
-	// Second call to get the actual data
-	bytesWritten, _, err := procGetSystemFirmwareTable.Call(
-		uintptr(RSMB),
-		0,
-		uintptr(unsafe.Pointer(&buffer[0])),
-		uintptr(size),
-	)
+// Chassis decodes every Type 3 (System Enclosure or Chassis) structure.
+func (snap *SMBIOSSnapshot) Chassis() ([]smbios.ChassisInformation, error) {
+	table, err := snap.table()
+	if err != nil {
+		return nil, err
+	}
 
-	if bytesWritten == 0 {
+	var chassis []smbios.ChassisInformation
+	for _, s := range table.All(smbios.TypeChassis) {
+		c, err := smbios.DecodeChassisInformation(s)
 		if err != nil {
-			return "", fmt.Errorf("failed to get firmware table data: %w", err)
+			return nil, err
 		}
-		return "", fmt.Errorf("failed to get firmware table data: 0 bytes written")
+		chassis = append(chassis, c)
 	}
+	return chassis, nil
+}
 
-	if bytesWritten < 8 {
-		return "", fmt.Errorf("received truncated SMBIOS data (less than header size)")
+// Processors decodes every Type 4 (Processor Information) structure, one per socket.
+func (snap *SMBIOSSnapshot) Processors() ([]smbios.ProcessorInformation, error) {
+	table, err := snap.table()
+	if err != nil {
+		return nil, err
 	}
 
-	smbiosData := (*RawSMBIOSData)(unsafe.Pointer(&buffer[0]))
-
-	tableDataOffset := unsafe.Offsetof(smbiosData.SMBIOSTableData)
-	if int(tableDataOffset) > len(buffer) {
-		return "", fmt.Errorf("internal error: invalid SMBIOS data buffer offset")
+	var processors []smbios.ProcessorInformation
+	for _, s := range table.All(smbios.TypeProcessorInformation) {
+		processor, err := smbios.DecodeProcessorInformation(s)
+		if err != nil {
+			return nil, err
+		}
+		processors = append(processors, processor)
 	}
-	tableData := buffer[tableDataOffset:]
+	return processors, nil
+}
 
-	if int(smbiosData.Length) > len(tableData) {
-		return "", fmt.Errorf("SMBIOS reported length (%d) exceeds buffer capacity (%d)", smbiosData.Length, len(tableData))
+// OEMStrings decodes every Type 11 (OEM Strings) and Type 12 (System Configuration Options) structure.
+func (snap *SMBIOSSnapshot) OEMStrings() ([]smbios.StringList, error) {
+	table, err := snap.table()
+	if err != nil {
+		return nil, err
 	}
-	tableData = tableData[:smbiosData.Length]
-
-	offset := 0
-	for offset < len(tableData) {
-		if offset+4 > len(tableData) {
-			break
-		}
 
-		header := (*SMBIOSHeader)(unsafe.Pointer(&tableData[offset]))
-
-		if header.Type == SmbiosTypeProcessorInformation {
-			if offset+16 > len(tableData) || int(header.Length) < 16 {
-				return "", fmt.Errorf("processor information structure (Type 4) too short (%d bytes) to contain ProcessorID (requires at least 16)", header.Length)
-			}
-
-			processorInfo := (*SmbiosProcessorInformation)(unsafe.Pointer(&tableData[offset]))
-
-			// Format ProcessorID bytes as a hexadecimal string
-			return fmt.Sprintf("%x", processorInfo.ProcessorID[:]), nil
-		}
-
-		structSize := int(header.Length)
-		stringOffset := offset + structSize
-
-		for stringOffset+1 < len(tableData) {
-			if tableData[stringOffset] == 0 && tableData[stringOffset+1] == 0 {
-				structSize += (stringOffset - (offset + int(header.Length))) + 2
-				break
-			}
-			stringOffset++
+	var lists []smbios.StringList
+	for _, s := range table.Structures {
+		if s.Type != smbios.TypeOEMStrings && s.Type != smbios.TypeConfigurationOptions {
+			continue
 		}
-
-		if stringOffset+1 >= len(tableData) {
-			if len(tableData) >= 2 && tableData[len(tableData)-2] == 0 && tableData[len(tableData)-1] == 0 {
-				structSize += len(tableData) - (offset + int(header.Length))
-			} else {
-				structSize = int(header.Length)
-			}
-		}
-
-		offset += structSize
-
-		if offset >= len(tableData) {
-			break
+		list, err := smbios.DecodeStringList(&s)
+		if err != nil {
+			return nil, err
 		}
+		lists = append(lists, list)
 	}
+	return lists, nil
+}
 
-	return "", fmt.Errorf("processor information structure (Type 4) not found")
+// Integrity reports structural anomalies in the raw SMBIOS buffer.
+func (snap *SMBIOSSnapshot) Integrity() ([]smbios.Anomaly, error) {
+	if snap.Raw == nil {
+		return nil, snap.Err
+	}
+	return smbios.CheckIntegrity(snap.Raw), nil
 }
 
-func extractString(data []byte, startOffset, index int) (string, error) {
-	offset := startOffset
-	for i := 1; i < index; i++ {
-		// Find the null terminator of the current string
-		for offset < len(data) && data[offset] != 0 {
-			offset++
-		}
-		// Skip past the null terminator
-		offset++
+// CHIDs computes the Computer Hardware IDs from the SMBIOS fields Windows hashes.
+func (snap *SMBIOSSnapshot) CHIDs() ([]smbios.CHID, error) {
+	table, err := snap.table()
+	if err != nil {
+		return nil, err
+	}
+	return smbios.ComputeCHIDs(table), nil
+}
 
-		if offset >= len(data) {
-			return "", fmt.Errorf("string index out of bounds")
-		}
+// GetComputerHardwareIds returns the CHIDs Windows stored at boot, as "{guid}" strings.
+func GetComputerHardwareIds() ([]string, error) {
+	k, err := Registry.OpenKey(reg.LocalMachine, `SYSTEM\CurrentControlSet\Control\SystemInformation`)
+	if err != nil {
+		return nil, err
 	}
+	defer k.Close()
 
-	end := bytes.IndexByte(data[offset:], 0)
-	if end == -1 {
-		return "", fmt.Errorf("malformed string data")
+	ids, err := k.StringsValue("ComputerHardwareIds")
+	if err != nil {
+		logger.Error("Failed to read ComputerHardwareIds value", err)
+		return nil, err
 	}
 
-	return string(data[offset : offset+end]), nil
+	return ids, nil
 }
 
 func GetMachineGUID() (string, error) {
-	k, err := registry.OpenKey(registry.LOCAL_MACHINE, `SOFTWARE\Microsoft\Cryptography`, registry.READ)
+	k, err := Registry.OpenKey(reg.LocalMachine, `SOFTWARE\Microsoft\Cryptography`)
 	if err != nil {
 		return "", err
 	}
 	defer k.Close()
 
-	s, _, err := k.GetStringValue("MachineGuid")
+	s, err := k.StringValue("MachineGuid")
 	if err != nil {
 		logger.Error("Failed to read MachineGuid value", err)
 		return "", err
@@ -0,0 +1,67 @@
+package native
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+// Windows API constants
+const (
+	RSMB = 0x52534D42
+)
+
+var (
+	kernel32                   = syscall.NewLazyDLL("kernel32.dll")
+	procGetSystemFirmwareTable = kernel32.NewProc("GetSystemFirmwareTable")
+)
+
+// getFirmwareTable returns the raw output of GetSystemFirmwareTable for the given provider and table ID.
+func getFirmwareTable(provider, tableID uint32) ([]byte, error) {
+	// First call with 0 buffer size to get required size
+	size, _, err := procGetSystemFirmwareTable.Call(
+		uintptr(provider),
+		uintptr(tableID),
+		0,
+		0,
+	)
+
+	if size == 0 {
+		return nil, fmt.Errorf("failed to get required buffer size for firmware table: %w", err)
+	}
+
+	// The table can grow between the two calls; GetSystemFirmwareTable then returns the
+	// new size without writing anything, so retry with a buffer that large
+	for attempt := 0; attempt < 3; attempt++ {
+		buffer := make([]byte, size)
+
+		// Second call to get the actual data
+		bytesWritten, _, err := procGetSystemFirmwareTable.Call(
+			uintptr(provider),
+			uintptr(tableID),
+			uintptr(unsafe.Pointer(&buffer[0])),
+			uintptr(size),
+		)
+
+		if bytesWritten == 0 {
+			return nil, fmt.Errorf("failed to get firmware table data: %w", err)
+		}
+		if bytesWritten <= size {
+			return buffer[:bytesWritten], nil
+		}
+		size = bytesWritten
+	}
+
+	return nil, fmt.Errorf("firmware table kept growing while it was read (last size %d bytes)", size)
+}
+
+// LiveSMBIOSSource reads the RSMB table of the running machine through GetSystemFirmwareTable.
+type LiveSMBIOSSource struct{}
+
+func (LiveSMBIOSSource) Name() string {
+	return "live"
+}
+
+func (LiveSMBIOSSource) ReadRaw() ([]byte, error) {
+	return getFirmwareTable(RSMB, 0)
+}
@@ -0,0 +1,35 @@
+//go:build !windows
+
+package native
+
+import (
+	"errors"
+
+	"github.com/seekehr/DevSpoofGOTest/acpi"
+)
+
+// errNoFirmwareTables is returned by the live sources off Windows, where
+// GetSystemFirmwareTable does not exist; use the sysfs or file sources instead.
+var errNoFirmwareTables = errors.New("live firmware tables are only available on Windows")
+
+// LiveSMBIOSSource reads the RSMB table of the running machine on Windows.
+type LiveSMBIOSSource struct{}
+
+func (LiveSMBIOSSource) Name() string {
+	return "live"
+}
+
+func (LiveSMBIOSSource) ReadRaw() ([]byte, error) {
+	return nil, errNoFirmwareTables
+}
+
+// LiveACPISource reads the ACPI tables of the running machine on Windows.
+type LiveACPISource struct{}
+
+func (LiveACPISource) Name() string {
+	return "live"
+}
+
+func (LiveACPISource) ReadTables() ([]acpi.Table, error) {
+	return nil, errNoFirmwareTables
+}
@@ -0,0 +1,50 @@
+package native
+
+import (
+	"strings"
+
+	"github.com/seekehr/DevSpoofGOTest/smbios"
+)
+
+// MemoryDevices decodes every Type 17 (Memory Device) structure, including empty slots.
+func (snap *SMBIOSSnapshot) MemoryDevices() ([]smbios.MemoryDevice, error) {
+	table, err := snap.table()
+	if err != nil {
+		return nil, err
+	}
+
+	var devices []smbios.MemoryDevice
+	for _, s := range table.All(smbios.TypeMemoryDevice) {
+		device, err := smbios.DecodeMemoryDevice(s)
+		if err != nil {
+			return nil, err
+		}
+		devices = append(devices, device)
+	}
+	return devices, nil
+}
+
+// MemorySlot is a slot as Win32_PhysicalMemory names it.
+type MemorySlot struct {
+	DeviceLocator string
+	BankLabel     string
+}
+
+// MatchMemorySlot returns the index of the unused slot describing the same slot as device,
+// matching on device locator and bank label, or -1 if there is none. A slot with only the
+// device locator in common is used when no slot matches both.
+func MatchMemorySlot(device smbios.MemoryDevice, slots []MemorySlot, used []bool) int {
+	fallback := -1
+	for i, slot := range slots {
+		if used[i] || strings.TrimSpace(slot.DeviceLocator) != strings.TrimSpace(device.DeviceLocator) {
+			continue
+		}
+		if strings.TrimSpace(slot.BankLabel) == strings.TrimSpace(device.BankLocator) {
+			return i
+		}
+		if fallback < 0 {
+			fallback = i
+		}
+	}
+	return fallback
+}
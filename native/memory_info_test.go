@@ -0,0 +1,34 @@
+package native
+
+import (
+	"testing"
+
+	"github.com/seekehr/DevSpoofGOTest/smbios"
+)
+
+func TestMatchMemorySlot(t *testing.T) {
+	slots := []MemorySlot{
+		{DeviceLocator: "DIMM1", BankLabel: "BANK 1"},
+		{DeviceLocator: "ChannelA-DIMM0", BankLabel: "BANK 0"},
+		{DeviceLocator: "ChannelA-DIMM0", BankLabel: "BANK 2"},
+		{DeviceLocator: "DIMM1 ", BankLabel: ""},
+	}
+	tests := []struct {
+		name   string
+		device smbios.MemoryDevice
+		used   []bool
+		want   int
+	}{
+		{"locator and bank", smbios.MemoryDevice{DeviceLocator: "ChannelA-DIMM0", BankLocator: "BANK 2"}, []bool{false, false, false, false}, 2},
+		{"padded locator", smbios.MemoryDevice{DeviceLocator: "DIMM1", BankLocator: ""}, []bool{false, false, false, false}, 3},
+		{"locator only", smbios.MemoryDevice{DeviceLocator: "ChannelA-DIMM0", BankLocator: "P0 CHANNEL A"}, []bool{false, false, false, false}, 1},
+		{"first match used", smbios.MemoryDevice{DeviceLocator: "ChannelA-DIMM0", BankLocator: "BANK 2"}, []bool{false, false, true, false}, 1},
+		{"all used", smbios.MemoryDevice{DeviceLocator: "ChannelA-DIMM0", BankLocator: "BANK 0"}, []bool{false, true, true, false}, -1},
+		{"no such slot", smbios.MemoryDevice{DeviceLocator: "DIMM3", BankLocator: "BANK 3"}, []bool{false, false, false, false}, -1},
+	}
+	for _, tt := range tests {
+		if got := MatchMemorySlot(tt.device, slots, tt.used); got != tt.want {
+			t.Errorf("%s: MatchMemorySlot = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package native
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	getComputerNameA   = kernel32.NewProc("GetComputerNameA")
+	getComputerNameW   = kernel32.NewProc("GetComputerNameW")
+	getComputerNameExW = kernel32.NewProc("GetComputerNameExW")
+
+	ws2_32      = syscall.NewLazyDLL("ws2_32.dll")
+	gethostname = ws2_32.NewProc("gethostname")
+
+	netapi32              = syscall.NewLazyDLL("netapi32.dll")
+	netGetJoinInformation = netapi32.NewProc("NetGetJoinInformation")
+	netApiBufferFree      = netapi32.NewProc("NetApiBufferFree")
+)
+
+// computerNameFormats are the COMPUTER_NAME_FORMAT values, in enum order.
+var computerNameFormats = []string{
+	"ComputerNameNetBIOS",
+	"ComputerNameDnsHostname",
+	"ComputerNameDnsDomain",
+	"ComputerNameDnsFullyQualified",
+	"ComputerNamePhysicalNetBIOS",
+	"ComputerNamePhysicalDnsHostname",
+	"ComputerNamePhysicalDnsDomain",
+	"ComputerNamePhysicalDnsFullyQualified",
+}
+
+// netJoinStatuses are the NETSETUP_JOIN_STATUS values, in enum order.
+var netJoinStatuses = []string{"Unknown", "Unjoined", "Workgroup", "Domain"}
+
+// ComputerName is the name one source reports for this machine.
+type ComputerName struct {
+	Source string
+	// Host is set for sources that report the machine's own (host or NetBIOS)
+	// name rather than a domain, so they can be compared with each other.
+	Host  bool
+	Value string
+	Err   error
+}
+
+func GetComputerNameA() (string, error) {
+	buf := make([]byte, 256)
+	size := uint32(len(buf))
+
+	ret, _, err := syscall.SyscallN(
+		getComputerNameA.Addr(),
+		uintptr(unsafe.Pointer(&buf[0])),
+		uintptr(unsafe.Pointer(&size)),
+		0, // Reserved - Must be 0
+	)
+
+	if ret == 0 {
+		return "", fmt.Errorf("GetComputerNameA failed: %v", err)
+	}
+
+	return DecodeANSI(buf[:size]), nil
+}
+
+func GetComputerNameW() (string, error) {
+	var buf [256]uint16
+	var size = uint32(len(buf))
+
+	ret, _, err := syscall.SyscallN(
+		getComputerNameW.Addr(),
+		uintptr(unsafe.Pointer(&buf[0])),
+		uintptr(unsafe.Pointer(&size)),
+		0,
+	)
+
+	if ret == 0 {
+		return "", err
+	}
+
+	return syscall.UTF16ToString(buf[:size]), nil
+}
+
+func GetComputerNameEx(format uint32) (string, error) {
+	// First call with a 0 size to get the required length, including the terminator
+	var size uint32
+	ret, _, err := syscall.SyscallN(getComputerNameExW.Addr(), uintptr(format), 0, uintptr(unsafe.Pointer(&size)))
+	if ret == 0 && err != syscall.ERROR_MORE_DATA {
+		return "", fmt.Errorf("GetComputerNameExW(%d) failed: %w", format, err)
+	}
+	if size == 0 {
+		return "", nil
+	}
+
+	buf := make([]uint16, size)
+	ret, _, err = syscall.SyscallN(
+		getComputerNameExW.Addr(),
+		uintptr(format),
+		uintptr(unsafe.Pointer(&buf[0])),
+		uintptr(unsafe.Pointer(&size)),
+	)
+	if ret == 0 {
+		return "", fmt.Errorf("GetComputerNameExW(%d) failed: %w", format, err)
+	}
+
+	return syscall.UTF16ToString(buf[:size]), nil
+}
+
+// GetWinsockHostname returns the name Winsock's gethostname reports.
+func GetWinsockHostname() (string, error) {
+	var data syscall.WSAData
+	if err := syscall.WSAStartup(uint32(0x202), &data); err != nil {
+		return "", fmt.Errorf("WSAStartup failed: %w", err)
+	}
+	defer syscall.WSACleanup()
+
+	buf := make([]byte, 256)
+	ret, _, err := syscall.SyscallN(gethostname.Addr(), uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf)))
+	if ret != 0 {
+		return "", fmt.Errorf("gethostname failed: %w", err)
+	}
+
+	return DecodeANSI([]byte(bytePointerToString(&buf[0]))), nil
+}
+
+// GetJoinInformation returns the domain or workgroup the machine is joined to and
+// the join status ("Workgroup", "Domain", ...).
+func GetJoinInformation() (string, string, error) {
+	var name *uint16
+	var status uint32
+	ret, _, _ := syscall.SyscallN(
+		netGetJoinInformation.Addr(),
+		0, // Local computer
+		uintptr(unsafe.Pointer(&name)),
+		uintptr(unsafe.Pointer(&status)),
+	)
+	if ret != 0 {
+		return "", "", fmt.Errorf("NetGetJoinInformation failed: %w", syscall.Errno(ret))
+	}
+	defer syscall.SyscallN(netApiBufferFree.Addr(), uintptr(unsafe.Pointer(name)))
+
+	statusName := fmt.Sprintf("Unknown (%d)", status)
+	if int(status) < len(netJoinStatuses) {
+		statusName = netJoinStatuses[status]
+	}
+	return windowsStringToString(name), statusName, nil
+}
+
+// windowsStringToString converts a null-terminated UTF-16 pointer to a Go string.
+func windowsStringToString(ptr *uint16) string {
+	if ptr == nil {
+		return ""
+	}
+
+	var chars []uint16
+	for p := unsafe.Pointer(ptr); *(*uint16)(p) != 0; p = unsafe.Add(p, 2) {
+		chars = append(chars, *(*uint16)(p))
+	}
+	return syscall.UTF16ToString(chars)
+}
+
+// GetComputerNames queries every source Windows exposes the computer name
+// through, so a spoofer that hooks only some of them shows up as a disagreement.
+func GetComputerNames() []ComputerName {
+	var names []ComputerName
+	add := func(source string, host bool, value string, err error) {
+		names = append(names, ComputerName{Source: source, Host: host, Value: value, Err: err})
+	}
+
+	value, err := GetComputerNameA()
+	add("GetComputerNameA", true, value, err)
+	value, err = GetComputerNameW()
+	add("GetComputerNameW", true, value, err)
+	for format, formatName := range computerNameFormats {
+		value, err = GetComputerNameEx(uint32(format))
+		// Only the hostname and NetBIOS formats name the machine itself
+		add("GetComputerNameExW "+formatName, format%4 < 2, value, err)
+	}
+
+	value, err = GetWinsockHostname()
+	add("gethostname", true, value, err)
+	value, err = os.Hostname()
+	add("os.Hostname", true, value, err)
+	value, ok := os.LookupEnv("COMPUTERNAME")
+	err = nil
+	if !ok {
+		err = fmt.Errorf("COMPUTERNAME is not set")
+	}
+	add("%COMPUTERNAME%", true, value, err)
+
+	value, err = registryString(`SYSTEM\CurrentControlSet\Control\ComputerName\ActiveComputerName`, "ComputerName")
+	add("ActiveComputerName", true, value, err)
+	value, err = registryString(`SYSTEM\CurrentControlSet\Control\ComputerName\ComputerName`, "ComputerName")
+	add("ComputerName", true, value, err)
+	value, err = registryString(`SYSTEM\CurrentControlSet\Services\Tcpip\Parameters`, "Hostname")
+	add("Tcpip Hostname", true, value, err)
+	value, err = registryString(`SYSTEM\CurrentControlSet\Services\Tcpip\Parameters`, "NV Hostname")
+	add("Tcpip NV Hostname", true, value, err)
+
+	domain, status, err := GetJoinInformation()
+	if err == nil {
+		domain += " (" + status + ")"
+	}
+	add("NetGetJoinInformation", false, domain, err)
+	return names
+}
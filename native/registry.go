@@ -0,0 +1,180 @@
+package native
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/seekehr/DevSpoofGOTest/reg"
+)
+
+// Registry is where every registry-based collector reads from.
+var Registry reg.Registry = reg.Live{}
+
+// SetRegistry selects the registry backend from a CLI spec: "live", "nt" for the live
+// registry read through ntdll, "hive:<dir>" for a directory of hive files (a copy of
+// System32\config) or the path of a .reg file exported by regedit.
+func SetRegistry(spec string) error {
+	if spec == "" || spec == "live" {
+		Registry = reg.Live{}
+		return nil
+	}
+	if spec == "nt" {
+		Registry = reg.NT{}
+		return nil
+	}
+	if strings.HasPrefix(spec, "hive:") {
+		hives, err := reg.LoadHiveDir(strings.TrimPrefix(spec, "hive:"))
+		if err != nil {
+			return fmt.Errorf("invalid registry source %q: %w", spec, err)
+		}
+		Registry = hives
+		return nil
+	}
+	m, err := reg.LoadRegFile(spec)
+	if err != nil {
+		return fmt.Errorf("invalid registry source %q: %w", spec, err)
+	}
+	Registry = m
+	return nil
+}
+
+// registryString reads one REG_SZ value under HKEY_LOCAL_MACHINE.
+func registryString(path, name string) (string, error) {
+	k, err := Registry.OpenKey(reg.LocalMachine, path)
+	if err != nil {
+		return "", err
+	}
+	defer k.Close()
+
+	return k.StringValue(name)
+}
+
+// OpenCurrentVersion opens HKLM\SOFTWARE\Microsoft\Windows NT\CurrentVersion, the key
+// the version getters read from.
+func OpenCurrentVersion() (reg.Key, error) {
+	return Registry.OpenKey(reg.LocalMachine, `SOFTWARE\Microsoft\Windows NT\CurrentVersion`)
+}
+
+// RegistryIdentifier is one registry-sourced identifier: the value Name under Path or,
+// with SubKeys set, the names of the subkeys of Path.
+type RegistryIdentifier struct {
+	Root    reg.Root
+	Path    string
+	Name    string
+	SubKeys bool
+}
+
+func (id RegistryIdentifier) String() string {
+	if id.SubKeys {
+		return string(id.Root) + `\` + id.Path + `\*`
+	}
+	return string(id.Root) + `\` + id.Path + `\` + id.Name
+}
+
+// RegistryIdentifiers lists every registry value the collectors read. None are under
+// HKEY_CLASSES_ROOT: reg.NT only opens its HKEY_LOCAL_MACHINE\SOFTWARE\Classes half,
+// while advapi32 merges in the user's classes, so CompareRegistryAPIs would report HKCR
+// keys the user overrides as mismatches.
+var RegistryIdentifiers = []RegistryIdentifier{
+	{Root: reg.LocalMachine, Path: `SOFTWARE\Microsoft\Cryptography`, Name: "MachineGuid"},
+	{Root: reg.LocalMachine, Path: `SOFTWARE\Microsoft\Windows NT\CurrentVersion`, Name: "ProductId"},
+	{Root: reg.LocalMachine, Path: `SOFTWARE\Microsoft\Windows NT\CurrentVersion`, Name: "DigitalProductId"},
+	{Root: reg.LocalMachine, Path: `SOFTWARE\Microsoft\Windows NT\CurrentVersion`, Name: "DigitalProductId4"},
+	{Root: reg.LocalMachine, Path: `SOFTWARE\Microsoft\Windows NT\CurrentVersion`, Name: "InstallDate"},
+	{Root: reg.LocalMachine, Path: `SOFTWARE\Microsoft\Windows NT\CurrentVersion`, Name: "InstallTime"},
+	{Root: reg.LocalMachine, Path: `SOFTWARE\Microsoft\Windows NT\CurrentVersion`, Name: "BuildGUID"},
+	{Root: reg.LocalMachine, Path: `SOFTWARE\Microsoft\Windows NT\CurrentVersion`, Name: "RegisteredOwner"},
+	{Root: reg.LocalMachine, Path: `SOFTWARE\Microsoft\Windows NT\CurrentVersion`, Name: "RegisteredOrganization"},
+	{Root: reg.LocalMachine, Path: `SOFTWARE\Microsoft\Windows NT\CurrentVersion`, Name: "ProductName"},
+	{Root: reg.LocalMachine, Path: `SOFTWARE\Microsoft\Windows NT\CurrentVersion`, Name: "EditionID"},
+	{Root: reg.LocalMachine, Path: `SOFTWARE\Microsoft\Windows NT\CurrentVersion`, Name: "DisplayVersion"},
+	{Root: reg.LocalMachine, Path: `SOFTWARE\Microsoft\Windows NT\CurrentVersion`, Name: "CurrentBuild"},
+	{Root: reg.LocalMachine, Path: `SOFTWARE\Microsoft\Windows NT\CurrentVersion`, Name: "UBR"},
+	{Root: reg.LocalMachine, Path: `SOFTWARE\Microsoft\SystemCertificates\ROOT\Certificates`, SubKeys: true},
+	{Root: reg.LocalMachine, Path: `SYSTEM\CurrentControlSet\Control\SystemInformation`, Name: "ComputerHardwareIds"},
+	{Root: reg.LocalMachine, Path: `SYSTEM\CurrentControlSet\Control\ComputerName\ActiveComputerName`, Name: "ComputerName"},
+	{Root: reg.LocalMachine, Path: `SYSTEM\CurrentControlSet\Control\ComputerName\ComputerName`, Name: "ComputerName"},
+	{Root: reg.LocalMachine, Path: `SYSTEM\CurrentControlSet\Services\Tcpip\Parameters`, Name: "Hostname"},
+	{Root: reg.LocalMachine, Path: `SYSTEM\CurrentControlSet\Services\Tcpip\Parameters`, Name: "NV Hostname"},
+}
+
+// ReadRegistryIdentifier reads id from r. A subkey list comes back as a REG_MULTI_SZ
+// value of the sorted names, so it compares like any other value.
+func ReadRegistryIdentifier(r reg.Registry, id RegistryIdentifier) (reg.Value, error) {
+	k, err := r.OpenKey(id.Root, id.Path)
+	if err != nil {
+		return reg.Value{}, err
+	}
+	defer k.Close()
+
+	if !id.SubKeys {
+		return k.Value(id.Name)
+	}
+	names, err := k.SubKeyNames()
+	if err != nil {
+		return reg.Value{}, err
+	}
+	sort.Strings(names)
+	return reg.Value{Type: reg.TypeMultiString, Data: reg.StringsData(names)}, nil
+}
+
+// RegistryComparison is one identifier read through two registry backends.
+type RegistryComparison struct {
+	Identifier RegistryIdentifier
+	Values     [2]reg.Value
+	Errs       [2]error
+}
+
+// Diverges reports whether the two reads disagree, including one of them lacking the value
+// or both failing for different reasons.
+func (c RegistryComparison) Diverges() bool {
+	if c.Errs[0] != nil && c.Errs[1] != nil {
+		return !sameRegistryError(c.Errs[0], c.Errs[1])
+	}
+	if c.Errs[0] != nil || c.Errs[1] != nil {
+		return true
+	}
+	return c.Values[0].Type != c.Values[1].Type || !bytes.Equal(c.Values[0].Data, c.Values[1].Data)
+}
+
+// sameRegistryError reports whether two failed reads failed for the same reason: both
+// with the same reg sentinel error or, failing that, with the same message.
+func sameRegistryError(a, b error) bool {
+	for _, target := range []error{reg.ErrNotExist, reg.ErrUnexpectedType} {
+		if errors.Is(a, target) || errors.Is(b, target) {
+			return errors.Is(a, target) && errors.Is(b, target)
+		}
+	}
+	return a.Error() == b.Error()
+}
+
+// CompareRegistries reads every identifier through both backends.
+func CompareRegistries(a, b reg.Registry) []RegistryComparison {
+	comparisons := make([]RegistryComparison, 0, len(RegistryIdentifiers))
+	for _, id := range RegistryIdentifiers {
+		c := RegistryComparison{Identifier: id}
+		c.Values[0], c.Errs[0] = ReadRegistryIdentifier(a, id)
+		c.Values[1], c.Errs[1] = ReadRegistryIdentifier(b, id)
+		comparisons = append(comparisons, c)
+	}
+	return comparisons
+}
+
+// CompareRegistryViews reads every identifier through KEY_WOW64_32KEY and KEY_WOW64_64KEY
+// of the live registry. Some keys are redirected to Wow6432Node and some are shared, so a
+// spoofer patching only one view shows up here.
+func CompareRegistryViews() []RegistryComparison {
+	return CompareRegistries(reg.Live{View: reg.View32}, reg.Live{View: reg.View64})
+}
+
+// CompareRegistryAPIs reads every identifier through advapi32 (RegQueryValueExW) and
+// through ntdll (NtQueryValueKey). Hooks on the Win32 registry functions never see the
+// ntdll reads, so the pair shows how deep registry spoofing goes. The ntdll paths always
+// name the native keys, so advapi32 reads the 64-bit view too; otherwise a 32-bit build
+// would see every key redirected to Wow6432Node as a mismatch.
+func CompareRegistryAPIs() []RegistryComparison {
+	return CompareRegistries(reg.Live{View: reg.View64}, reg.NT{})
+}
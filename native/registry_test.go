@@ -0,0 +1,240 @@
+package native
+
+import (
+	"encoding/binary"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/seekehr/DevSpoofGOTest/reg"
+)
+
+// useRegFile points Registry at a .reg fixture for the duration of the test.
+func useRegFile(t *testing.T, name string) {
+	t.Helper()
+	m, err := reg.LoadRegFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := Registry
+	Registry = m
+	t.Cleanup(func() { Registry = saved })
+}
+
+func TestGetMachineGUID(t *testing.T) {
+	useRegFile(t, "currentversion.reg")
+	if got, err := GetMachineGUID(); err != nil || got != "6f1ed2c5-3f6a-4c0b-9d5e-2b7a8c1d4e3f" {
+		t.Errorf("GetMachineGUID = %q, %v", got, err)
+	}
+
+	Registry = reg.NewMemory("empty")
+	if _, err := GetMachineGUID(); err == nil {
+		t.Error("GetMachineGUID on an empty registry returned no error")
+	}
+}
+
+func TestCurrentVersionGetters(t *testing.T) {
+	useRegFile(t, "currentversion.reg")
+	k, err := OpenCurrentVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer k.Close()
+
+	strs := []struct {
+		name string
+		get  func(reg.Key) (string, error)
+		want string
+	}{
+		{"GetProductID", GetProductID, "00330-80000-00000-AA478"},
+		{"GetEditionID", GetEditionID, "Professional"},
+		{"GetProductKey", GetProductKey, "W269N-WFGWX-YVC9B-4J6C9-T83GX"},
+		{"GetDigitalProductIDProductID", GetDigitalProductIDProductID, "00330-80000-00000-AA478"},
+		{"GetInstallDate", GetInstallDate, "Wed, 31 Mar 2021 11:20:42 +0000"},
+		{"GetInstallTime", GetInstallTime, "2021-03-31 11:20:42 UTC"},
+	}
+	for _, tt := range strs {
+		if got, err := tt.get(k); err != nil || got != tt.want {
+			t.Errorf("%s = %q, %v; want %q", tt.name, got, err, tt.want)
+		}
+	}
+	if got, err := GetVersionString(k, "DisplayVersion"); err != nil || got != "22H2" {
+		t.Errorf(`GetVersionString("DisplayVersion") = %q, %v; want "22H2"`, got, err)
+	}
+	if got, err := GetUBR(k); err != nil || got != 3787 {
+		t.Errorf("GetUBR = %d, %v; want 3787", got, err)
+	}
+	if got, err := GetDigitalID(k); err != nil || len(got) != 2*0xA4 {
+		t.Errorf("GetDigitalID = %d hex digits, %v; want %d", len(got), err, 2*0xA4)
+	}
+	// InstallTime carries a sub-second fraction that the drift ignores
+	if got, err := GetInstallTimeDrift(k); err != nil || got != 0 {
+		t.Errorf("GetInstallTimeDrift = %v, %v; want 0", got, err)
+	}
+
+	dpid4, err := GetDigitalProductID4(k)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dpid4.EditionID != "Professional" || dpid4.KeyType != "Volume:GVLK" || dpid4.ProductCode() != "03308" {
+		t.Errorf("GetDigitalProductID4 = EditionID %q, KeyType %q, ProductCode %q", dpid4.EditionID, dpid4.KeyType, dpid4.ProductCode())
+	}
+
+	if _, err := GetVersionString(k, "BuildGUID"); err == nil {
+		t.Error("missing BuildGUID returned no error")
+	}
+}
+
+func TestFiletimeToTime(t *testing.T) {
+	tests := []struct {
+		filetime uint64
+		want     string
+	}{
+		{0, "1601-01-01 00:00:00 UTC"},
+		// One interval before the Unix epoch
+		{116444736000000000 - 1, "1969-12-31 23:59:59.9999999 UTC"},
+		{116444736000000000, "1970-01-01 00:00:00 UTC"},
+		{116444736000000000 - 140*24*3600*1e7, "1969-08-14 00:00:00 UTC"},
+		{0x1D7261FE2C6B787, "2021-03-31 11:20:42.1234567 UTC"},
+	}
+	for _, tt := range tests {
+		got, err := FiletimeToTime(tt.filetime)
+		if err != nil {
+			t.Errorf("FiletimeToTime(%d): %v", tt.filetime, err)
+			continue
+		}
+		if s := got.Format("2006-01-02 15:04:05.9999999 MST"); s != tt.want {
+			t.Errorf("FiletimeToTime(%d) = %s, want %s", tt.filetime, s, tt.want)
+		}
+	}
+	if _, err := FiletimeToTime(1 << 63); err == nil {
+		t.Error("FILETIME above MaxInt64 returned no error")
+	}
+
+	hexTests := []struct {
+		hex  string
+		want string
+	}{
+		{"0", "1601-01-01 00:00:00 UTC"},
+		{"0x19DB1DED53E7FFF", "1969-12-31 23:59:59 UTC"},
+		{"1d7261fe2c6b787", "2021-03-31 11:20:42 UTC"},
+	}
+	for _, tt := range hexTests {
+		if got, err := HexFiletimeToFormattedTime(tt.hex); err != nil || got != tt.want {
+			t.Errorf("HexFiletimeToFormattedTime(%q) = %q, %v; want %q", tt.hex, got, err, tt.want)
+		}
+	}
+}
+
+func TestGetInstallTimeDrift(t *testing.T) {
+	const path = `SOFTWARE\Microsoft\Windows NT\CurrentVersion`
+	const installDate = 1617189642
+	tests := []struct {
+		offset time.Duration
+		want   time.Duration
+	}{
+		{900 * time.Millisecond, 0},
+		// 1.9s apart is still a whole second off once the fraction is dropped
+		{1900 * time.Millisecond, time.Second},
+		{-time.Second, time.Second},
+		{time.Hour, time.Hour},
+	}
+	for _, tt := range tests {
+		m := reg.NewMemory("drift")
+		m.SetValue(reg.LocalMachine, path, "InstallDate", reg.Value{Type: reg.TypeDWord, Data: binary.LittleEndian.AppendUint32(nil, installDate)})
+		filetime := uint64(installDate*1e7 + 116444736000000000 + int64(tt.offset/100))
+		m.SetValue(reg.LocalMachine, path, "InstallTime", reg.Value{Type: reg.TypeQWord, Data: binary.LittleEndian.AppendUint64(nil, filetime)})
+		k, err := m.OpenKey(reg.LocalMachine, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, err := GetInstallTimeDrift(k); err != nil || got != tt.want {
+			t.Errorf("InstallTime %v after InstallDate: drift = %v, %v; want %v", tt.offset, got, err, tt.want)
+		}
+	}
+}
+
+// failingRegistry fails every OpenKey with err.
+type failingRegistry struct{ err error }
+
+func (r failingRegistry) Name() string { return "failing" }
+
+func (r failingRegistry) OpenKey(reg.Root, string) (reg.Key, error) { return nil, r.err }
+
+// unsortedRegistry lists subkeys in reverse order, as a live or hive backend may
+// return them in any order.
+type unsortedRegistry struct{ reg.Registry }
+
+type unsortedKey struct{ reg.Key }
+
+func (r unsortedRegistry) OpenKey(root reg.Root, path string) (reg.Key, error) {
+	k, err := r.Registry.OpenKey(root, path)
+	if err != nil {
+		return nil, err
+	}
+	return unsortedKey{k}, nil
+}
+
+func (k unsortedKey) SubKeyNames() ([]string, error) {
+	names, err := k.Key.SubKeyNames()
+	for i, j := 0, len(names)-1; i < j; i, j = i+1, j-1 {
+		names[i], names[j] = names[j], names[i]
+	}
+	return names, err
+}
+
+func TestReadRegistryIdentifierSubKeys(t *testing.T) {
+	const path = `SOFTWARE\Microsoft\SystemCertificates\ROOT\Certificates`
+	m := reg.NewMemory("certs")
+	for _, name := range []string{"CDD4EEAE", "0563B8630D62D75A", "D69B561148F01C77"} {
+		m.SetValue(reg.LocalMachine, path+`\`+name, "Blob", reg.Value{Type: reg.TypeBinary})
+	}
+	v, err := ReadRegistryIdentifier(unsortedRegistry{m}, RegistryIdentifier{Root: reg.LocalMachine, Path: path, SubKeys: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"0563B8630D62D75A", "CDD4EEAE", "D69B561148F01C77"}
+	if got, err := v.AsStrings(); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("subkeys = %q, %v; want %q", got, err, want)
+	}
+}
+
+func TestCompareRegistries(t *testing.T) {
+	id := RegistryIdentifier{Root: reg.LocalMachine, Path: `SOFTWARE\Microsoft\Cryptography`, Name: "MachineGuid"}
+	guid := reg.Value{Type: reg.TypeString, Data: reg.StringData("6f1ed2c5-3f6a-4c0b-9d5e-2b7a8c1d4e3f")}
+	with := func(v reg.Value) reg.Registry {
+		m := reg.NewMemory("memory")
+		m.SetValue(id.Root, id.Path, id.Name, v)
+		return m
+	}
+	accessDenied := errors.New("Access is denied.")
+
+	tests := []struct {
+		name     string
+		a, b     reg.Registry
+		diverges bool
+	}{
+		{"same value", with(guid), with(guid), false},
+		{"data mismatch", with(guid), with(reg.Value{Type: reg.TypeString, Data: reg.StringData("00000000-0000-0000-0000-000000000000")}), true},
+		{"type mismatch", with(guid), with(reg.Value{Type: reg.TypeExpandString, Data: guid.Data}), true},
+		{"missing in one", with(guid), reg.NewMemory("empty"), true},
+		{"missing in both", reg.NewMemory("empty"), reg.NewMemory("empty"), false},
+		{"same error in both", failingRegistry{accessDenied}, failingRegistry{errors.New("Access is denied.")}, false},
+		{"different errors", reg.NewMemory("empty"), failingRegistry{accessDenied}, true},
+	}
+	for _, tt := range tests {
+		comparisons := CompareRegistries(tt.a, tt.b)
+		if len(comparisons) != len(RegistryIdentifiers) {
+			t.Fatalf("%s: got %d comparisons, want %d", tt.name, len(comparisons), len(RegistryIdentifiers))
+		}
+		c := comparisons[0]
+		if c.Identifier != id {
+			t.Fatalf("first comparison is %s, want %s", c.Identifier, id)
+		}
+		if got := c.Diverges(); got != tt.diverges {
+			t.Errorf("%s: Diverges = %v (values %v, errors %v), want %v", tt.name, got, c.Values, c.Errs, tt.diverges)
+		}
+	}
+}
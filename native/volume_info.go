@@ -0,0 +1,92 @@
+package native
+
+import "fmt"
+
+// VolumeInformation is what GetVolumeInformation reports for one root path.
+type VolumeInformation struct {
+	Label              string
+	FileSystem         string
+	Flags              uint32
+	MaxComponentLength uint32
+	Serial             uint32
+}
+
+// VolumeQuery is one root path queried through both GetVolumeInformationA and
+// GetVolumeInformationW.
+type VolumeQuery struct {
+	Root       string
+	A, W       VolumeInformation
+	ErrA, ErrW error
+}
+
+// SameAs reports whether q got the same answers (or the same failures) as other.
+func (q VolumeQuery) SameAs(other VolumeQuery) bool {
+	return q.A == other.A && q.W == other.W && (q.ErrA == nil) == (other.ErrA == nil) && (q.ErrW == nil) == (other.ErrW == nil)
+}
+
+// Volume is one volume and every path it is mounted at.
+type Volume struct {
+	// Name is the volume GUID path, "\\?\Volume{...}\".
+	Name        string
+	MountPoints []string
+	// MountPointsErr is set when the mount points could not be listed.
+	MountPointsErr error
+	// Queries holds the volume GUID path first, then each mount point.
+	Queries []VolumeQuery
+}
+
+// fileSystemFlags names the FILE_* bits GetVolumeInformation returns, by bit.
+var fileSystemFlags = [32]string{
+	"FILE_CASE_SENSITIVE_SEARCH",
+	"FILE_CASE_PRESERVED_NAMES",
+	"FILE_UNICODE_ON_DISK",
+	"FILE_PERSISTENT_ACLS",
+	"FILE_FILE_COMPRESSION",
+	"FILE_VOLUME_QUOTAS",
+	"FILE_SUPPORTS_SPARSE_FILES",
+	"FILE_SUPPORTS_REPARSE_POINTS",
+	"FILE_SUPPORTS_REMOTE_STORAGE",
+	"FILE_RETURNS_CLEANUP_RESULT_INFO",
+	"FILE_SUPPORTS_POSIX_UNLINK_RENAME",
+	"FILE_SUPPORTS_BYPASS_IO",
+	"FILE_SUPPORTS_STREAM_SNAPSHOTS",
+	"FILE_SUPPORTS_CASE_SENSITIVE_DIRS",
+	"",
+	"FILE_VOLUME_IS_COMPRESSED",
+	"FILE_SUPPORTS_OBJECT_IDS",
+	"FILE_SUPPORTS_ENCRYPTION",
+	"FILE_NAMED_STREAMS",
+	"FILE_READ_ONLY_VOLUME",
+	"FILE_SEQUENTIAL_WRITE_ONCE",
+	"FILE_SUPPORTS_TRANSACTIONS",
+	"FILE_SUPPORTS_HARD_LINKS",
+	"FILE_SUPPORTS_EXTENDED_ATTRIBUTES",
+	"FILE_SUPPORTS_OPEN_BY_FILE_ID",
+	"FILE_SUPPORTS_USN_JOURNAL",
+	"FILE_SUPPORTS_INTEGRITY_STREAMS",
+	"FILE_SUPPORTS_BLOCK_REFCOUNTING",
+	"FILE_SUPPORTS_SPARSE_VDL",
+	"FILE_DAX_VOLUME",
+	"FILE_SUPPORTS_GHOSTING",
+	"",
+}
+
+// FlagNames lists the file system flags that are set; undocumented bits are shown in hex.
+func (v VolumeInformation) FlagNames() []string {
+	var names []string
+	for bit, name := range fileSystemFlags {
+		if v.Flags&(1<<bit) == 0 {
+			continue
+		}
+		if name == "" {
+			name = fmt.Sprintf("0x%08X", uint32(1)<<bit)
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// SerialString formats the serial the way dir and vol print it: "XXXX-XXXX".
+func (v VolumeInformation) SerialString() string {
+	return fmt.Sprintf("%04X-%04X", v.Serial>>16, v.Serial&0xFFFF)
+}
@@ -0,0 +1,85 @@
+package native
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestFlagNames(t *testing.T) {
+	tests := []struct {
+		flags uint32
+		want  []string
+	}{
+		{0, nil},
+		// Bits 14 and 31 have no FILE_* name
+		{0x80084003, []string{"FILE_CASE_SENSITIVE_SEARCH", "FILE_CASE_PRESERVED_NAMES", "0x00004000", "FILE_READ_ONLY_VOLUME", "0x80000000"}},
+		{0x03E700FF, []string{
+			"FILE_CASE_SENSITIVE_SEARCH", "FILE_CASE_PRESERVED_NAMES", "FILE_UNICODE_ON_DISK", "FILE_PERSISTENT_ACLS",
+			"FILE_FILE_COMPRESSION", "FILE_VOLUME_QUOTAS", "FILE_SUPPORTS_SPARSE_FILES", "FILE_SUPPORTS_REPARSE_POINTS",
+			"FILE_SUPPORTS_OBJECT_IDS", "FILE_SUPPORTS_ENCRYPTION", "FILE_NAMED_STREAMS",
+			"FILE_SUPPORTS_TRANSACTIONS", "FILE_SUPPORTS_HARD_LINKS", "FILE_SUPPORTS_EXTENDED_ATTRIBUTES",
+			"FILE_SUPPORTS_OPEN_BY_FILE_ID", "FILE_SUPPORTS_USN_JOURNAL",
+		}},
+	}
+	for _, tt := range tests {
+		if got := (VolumeInformation{Flags: tt.flags}).FlagNames(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("FlagNames(0x%08X) = %q, want %q", tt.flags, got, tt.want)
+		}
+	}
+}
+
+func TestSerialString(t *testing.T) {
+	tests := []struct {
+		serial uint32
+		want   string
+	}{
+		{0x0001ABCD, "0001-ABCD"},
+		{0xABCD0001, "ABCD-0001"},
+		{0, "0000-0000"},
+		{0xFFFFFFFF, "FFFF-FFFF"},
+	}
+	for _, tt := range tests {
+		if got := (VolumeInformation{Serial: tt.serial}).SerialString(); got != tt.want {
+			t.Errorf("SerialString(0x%08X) = %q, want %q", tt.serial, got, tt.want)
+		}
+	}
+}
+
+func TestVolumeQuerySameAs(t *testing.T) {
+	info := VolumeInformation{Label: "System", FileSystem: "NTFS", Flags: 0x03E700FF, MaxComponentLength: 255, Serial: 0x0001ABCD}
+	q := VolumeQuery{Root: `C:\`, A: info, W: info}
+	errA := errors.New("access denied")
+
+	spoofed := info
+	spoofed.Serial = 0x1234ABCD
+	tests := []struct {
+		name  string
+		other VolumeQuery
+		want  bool
+	}{
+		{"identical", q, true},
+		// Only the answers are compared, not the path they were read from
+		{"other root", VolumeQuery{Root: `\\?\Volume{0}\`, A: info, W: info}, true},
+		{"W serial differs", VolumeQuery{Root: `C:\`, A: info, W: spoofed}, false},
+		{"only ErrA set", VolumeQuery{Root: `C:\`, W: info, ErrA: errA}, false},
+		{"only ErrW set", VolumeQuery{Root: `C:\`, A: info, ErrW: errA}, false},
+	}
+	for _, tt := range tests {
+		if got := q.SameAs(tt.other); got != tt.want {
+			t.Errorf("%s: SameAs = %v, want %v", tt.name, got, tt.want)
+		}
+		if got := tt.other.SameAs(q); got != tt.want {
+			t.Errorf("%s: reversed SameAs = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// Two failures count as the same answer whatever their message
+	failedA := VolumeQuery{Root: `C:\`, W: info, ErrA: errA}
+	if other := (VolumeQuery{Root: `C:\`, W: info, ErrA: errors.New("not ready")}); !failedA.SameAs(other) {
+		t.Error("queries failing in A with different errors are not the same")
+	}
+	if other := (VolumeQuery{Root: `C:\`, ErrA: errA, ErrW: errA}); failedA.SameAs(other) {
+		t.Error("query failing in A is the same as one failing in both")
+	}
+}
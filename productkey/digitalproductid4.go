@@ -0,0 +1,115 @@
+package productkey
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+)
+
+// DigitalProductID4Size is the size of the DigitalProductId4 structure.
+const DigitalProductID4Size = 0x4F8
+
+// productIDOffset is where DigitalProductId (version 3) keeps the ProductId string.
+const productIDOffset = 0x08
+
+// DigitalProductID4 is the decoded DigitalProductId4 registry value.
+type DigitalProductID4 struct {
+	Size    uint32
+	Version uint32
+	// AdvancedPID is the extended product ID, e.g. "03612-03308-000-000000-00-1033-19041.0000-0012021".
+	AdvancedPID  string
+	ActivationID string
+	OEMID        string
+	EditionType  string
+	IsUpgrade    bool
+	// CDKey is the encoded key; CDKey256Hash and Hash256 are SHA-256 digests.
+	CDKey        [16]byte
+	CDKey256Hash [32]byte
+	Hash256      [32]byte
+	EditionID    string
+	// KeyType is the license channel, e.g. "Retail" or "Volume:GVLK".
+	KeyType string
+	EULA    string
+}
+
+// DecodeDigitalProductID4 decodes a DigitalProductId4 blob.
+func DecodeDigitalProductID4(b []byte) (DigitalProductID4, error) {
+	if len(b) < DigitalProductID4Size {
+		return DigitalProductID4{}, fmt.Errorf("DigitalProductId4 is %d bytes, too short (requires at least %d)", len(b), DigitalProductID4Size)
+	}
+
+	d := DigitalProductID4{
+		Size:         binary.LittleEndian.Uint32(b[0x000:]),
+		Version:      binary.LittleEndian.Uint32(b[0x004:]),
+		AdvancedPID:  wideString(b[0x008:0x088]),
+		ActivationID: wideString(b[0x088:0x108]),
+		OEMID:        wideString(b[0x108:0x118]),
+		EditionType:  wideString(b[0x118:0x320]),
+		IsUpgrade:    b[0x320] != 0,
+		EditionID:    wideString(b[0x378:0x3F8]),
+		KeyType:      wideString(b[0x3F8:0x478]),
+		EULA:         wideString(b[0x478:0x4F8]),
+	}
+	copy(d.CDKey[:], b[0x328:0x338])
+	copy(d.CDKey256Hash[:], b[0x338:0x358])
+	copy(d.Hash256[:], b[0x358:0x378])
+	return d, nil
+}
+
+// ProductCode returns the second group of AdvancedPID, which repeats the product code
+// ProductId carries, or "" when AdvancedPID has no second group.
+func (d DigitalProductID4) ProductCode() string {
+	groups := strings.Split(d.AdvancedPID, "-")
+	if len(groups) < 2 {
+		return ""
+	}
+	return groups[1]
+}
+
+// ProductCode returns the product code of a ProductId in the form AdvancedPID repeats
+// it: the first group without its leading digit, then the first digit of the second
+// group, so "00330-80000-00000-AA478" gives "03308". Older ProductIds such as
+// "00371-OEM-8992671-00524" have no such code and give "".
+func ProductCode(productID string) string {
+	if len(productID) < 7 || productID[5] != '-' {
+		return ""
+	}
+	code := productID[1:5] + productID[6:7]
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return ""
+		}
+	}
+	return code
+}
+
+// ProductID returns the ProductId string DigitalProductId carries at offset 8,
+// which Windows keeps in step with the ProductId value.
+func ProductID(digitalProductID []byte) (string, error) {
+	const length = 24
+	if len(digitalProductID) < productIDOffset+length {
+		return "", fmt.Errorf("DigitalProductId is %d bytes, too short to hold a product ID (requires at least %d)", len(digitalProductID), productIDOffset+length)
+	}
+
+	id := digitalProductID[productIDOffset : productIDOffset+length]
+	for i, c := range id {
+		if c == 0 {
+			return string(id[:i]), nil
+		}
+	}
+	return string(id), nil
+}
+
+// wideString decodes a NUL-terminated UTF-16LE field.
+func wideString(b []byte) string {
+	chars := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		c := binary.LittleEndian.Uint16(b[i:])
+		if c == 0 {
+			break
+		}
+		chars = append(chars, c)
+	}
+	return string(utf16.Decode(chars))
+}
@@ -0,0 +1,67 @@
+// Package productkey decodes the Windows product key embedded in the
+// DigitalProductId registry value.
+package productkey
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// keyOffset is where the 15-byte encoded key starts in DigitalProductId.
+	keyOffset = 52
+	keyLength = 15
+	// keyDigits is the base-24 alphabet product keys are written in.
+	keyDigits = "BCDFGHJKMPQRTVWXY2346789"
+)
+
+// ErrNoKey is returned when the key bytes are all zero, as on machines activated
+// by digital license, which would otherwise decode to BBBBB-BBBBB-BBBBB-BBBBB-BBBBB.
+var ErrNoKey = errors.New("DigitalProductId holds no product key")
+
+// Decode extracts the product key from a DigitalProductId blob in the
+// XXXXX-XXXXX-XXXXX-XXXXX-XXXXX form, covering both the legacy base-24 encoding
+// and the Windows 8+ one, whose keys carry an "N" placed by the first digit.
+func Decode(digitalProductID []byte) (string, error) {
+	if len(digitalProductID) < keyOffset+keyLength {
+		return "", fmt.Errorf("DigitalProductId is %d bytes, too short to hold a product key (requires at least %d)", len(digitalProductID), keyOffset+keyLength)
+	}
+
+	var key [keyLength]byte
+	copy(key[:], digitalProductID[keyOffset:])
+	// Windows 8 and later keys are flagged in the last byte; the test and the
+	// bit cleared afterwards follow Microsoft's own key-extraction script
+	isWin8 := key[keyLength-1]/6&1 == 1
+	key[keyLength-1] &^= 0x08
+	if key == [keyLength]byte{} {
+		return "", ErrNoKey
+	}
+
+	// Repeatedly divide the 120-bit little-endian number by 24; the remainders
+	// are the key digits from last to first
+	var digits [25]byte
+	last := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		current := 0
+		for j := keyLength - 1; j >= 0; j-- {
+			current = current<<8 | int(key[j])
+			key[j] = byte(current / 24)
+			current %= 24
+		}
+		digits[i] = keyDigits[current]
+		last = current
+	}
+
+	decoded := string(digits[:])
+	if isWin8 {
+		// The first digit is dropped and "N" is inserted after the next last digits
+		decoded = decoded[1:1+last] + "N" + decoded[1+last:]
+	}
+
+	groups := make([]string, 0, 5)
+	for i := 0; i < len(decoded); i += 5 {
+		groups = append(groups, decoded[i:i+5])
+	}
+	return strings.Join(groups, "-"), nil
+}
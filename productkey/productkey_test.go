@@ -0,0 +1,112 @@
+package productkey
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	raw, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return raw
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		fixture   string
+		key       string
+		productID string
+	}{
+		// Windows 10 Pro generic key, "N" in the middle
+		{"dpid_win10.bin", "W269N-WFGWX-YVC9B-4J6C9-T83GX", "00330-80000-00000-AA478"},
+		// Windows 10 Enterprise generic key, "N" first
+		{"dpid_n_first.bin", "NPPR9-FWDCX-D2C8J-H872K-2YT43", "00329-00000-00003-AA066"},
+		// Windows 7 Professional OEM key, legacy encoding
+		{"dpid_win7.bin", "32KD2-K9CTF-M3DJT-4J3WC-733WD", "00371-OEM-8992671-00524"},
+	}
+	for _, tt := range tests {
+		raw := readFixture(t, tt.fixture)
+		key, err := Decode(raw)
+		if err != nil || key != tt.key {
+			t.Errorf("%s: Decode = %q, %v; want %q", tt.fixture, key, err, tt.key)
+		}
+		id, err := ProductID(raw)
+		if err != nil || id != tt.productID {
+			t.Errorf("%s: ProductID = %q, %v; want %q", tt.fixture, id, err, tt.productID)
+		}
+	}
+}
+
+func TestDecodeNoKey(t *testing.T) {
+	if _, err := Decode(readFixture(t, "dpid_digital_license.bin")); !errors.Is(err, ErrNoKey) {
+		t.Errorf("all-zero key: err = %v, want ErrNoKey", err)
+	}
+	if _, err := Decode(readFixture(t, "dpid_win10.bin")[:keyOffset+keyLength-1]); err == nil {
+		t.Error("blob cut off inside the key decoded without error")
+	}
+}
+
+func TestProductCode(t *testing.T) {
+	dpid4 := DigitalProductID4{AdvancedPID: "03612-03308-000-000000-00-1033-19041.0000-0012021"}
+	if got := dpid4.ProductCode(); got != "03308" {
+		t.Errorf("DigitalProductID4.ProductCode = %q, want %q", got, "03308")
+	}
+	if got := (DigitalProductID4{}).ProductCode(); got != "" {
+		t.Errorf("empty AdvancedPID: ProductCode = %q, want \"\"", got)
+	}
+
+	tests := []struct{ productID, want string }{
+		{"00330-80000-00000-AA478", "03308"},
+		{"00329-00000-00003-AA066", "03290"},
+		{"00371-OEM-8992671-00524", ""},
+		{"00330", ""},
+	}
+	for _, tt := range tests {
+		if got := ProductCode(tt.productID); got != tt.want {
+			t.Errorf("ProductCode(%q) = %q, want %q", tt.productID, got, tt.want)
+		}
+	}
+}
+
+func TestDecodeDigitalProductID4(t *testing.T) {
+	raw := readFixture(t, "dpid4_win10.bin")
+	want := DigitalProductID4{
+		Size:         DigitalProductID4Size,
+		Version:      4,
+		AdvancedPID:  "03612-03308-000-000000-00-1033-19041.0000-0012021",
+		ActivationID: "{2de67392-b7a7-462a-b1ca-108dd189f588}",
+		// Fills its field with no terminator
+		OEMID:       "OEMID-01",
+		EditionType: "X19-98841",
+		IsUpgrade:   true,
+		EditionID:   "Professional",
+		KeyType:     "Volume:GVLK",
+		EULA:        "Volume",
+	}
+	for i := range want.CDKey {
+		want.CDKey[i] = byte(0x10 + i)
+	}
+	for i := range want.CDKey256Hash {
+		want.CDKey256Hash[i] = byte(0x20 + i)
+	}
+	for i := range want.Hash256 {
+		want.Hash256[i] = byte(0x40 + i)
+	}
+
+	got, err := DecodeDigitalProductID4(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("DecodeDigitalProductID4 = %+v, want %+v", got, want)
+	}
+
+	if _, err := DecodeDigitalProductID4(raw[:DigitalProductID4Size-1]); err == nil {
+		t.Error("blob one byte short decoded without error")
+	}
+}
@@ -0,0 +1,516 @@
+package reg
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// hiveBinsOffset is where the hive bins start; cell offsets are relative to it.
+	hiveBinsOffset = 0x1000
+	// bigDataSegmentSize is the most data one "db" segment holds.
+	bigDataSegmentSize = 16344
+
+	keyCompressedName   = 0x0020
+	valueCompressedName = 0x0001
+)
+
+// ErrCorruptHive is returned when a hive file's structures point outside of it
+// or carry the wrong signatures.
+var ErrCorruptHive = errors.New("corrupt registry hive")
+
+// HiveFile is a registry hive file (REGF), such as SOFTWARE or SYSTEM copied
+// from a machine or an image. It is read as-is: pending transaction logs are
+// not replayed, so a hive copied from a running system may be slightly stale.
+type HiveFile struct {
+	name         string
+	bins         []byte
+	minorVersion uint32
+	root         uint32
+}
+
+// OpenHiveFile reads a hive file into memory.
+func OpenHiveFile(path string) (*HiveFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	h, err := ParseHive(path, data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return h, nil
+}
+
+// ParseHive parses the contents of a hive file.
+func ParseHive(name string, data []byte) (*HiveFile, error) {
+	if len(data) < hiveBinsOffset || string(data[:4]) != "regf" {
+		return nil, fmt.Errorf("%w: missing regf base block", ErrCorruptHive)
+	}
+	size := int(binary.LittleEndian.Uint32(data[0x28:]))
+	bins := data[hiveBinsOffset:]
+	if size < len(bins) {
+		bins = bins[:size]
+	}
+	h := &HiveFile{
+		name:         name,
+		bins:         bins,
+		minorVersion: binary.LittleEndian.Uint32(data[0x18:]),
+		root:         binary.LittleEndian.Uint32(data[0x24:]),
+	}
+	if _, err := h.keyNode(h.root); err != nil {
+		return nil, fmt.Errorf("root key: %w", err)
+	}
+	return h, nil
+}
+
+// Root opens the hive's root key.
+func (h *HiveFile) Root() (Key, error) {
+	k, err := h.openKey(h.root)
+	if err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// cell returns the data of the allocated cell at offset.
+func (h *HiveFile) cell(offset uint32) ([]byte, error) {
+	if int64(offset)+4 > int64(len(h.bins)) {
+		return nil, fmt.Errorf("%w: cell 0x%X is outside the hive", ErrCorruptHive, offset)
+	}
+	size := int32(binary.LittleEndian.Uint32(h.bins[offset:]))
+	// Allocated cells have a negative size that includes the size field
+	if size >= 0 {
+		return nil, fmt.Errorf("%w: cell 0x%X is not allocated", ErrCorruptHive, offset)
+	}
+	end := int64(offset) + int64(-size)
+	if -size < 4 || end > int64(len(h.bins)) {
+		return nil, fmt.Errorf("%w: cell 0x%X runs past the end of the hive", ErrCorruptHive, offset)
+	}
+	return h.bins[offset+4 : end], nil
+}
+
+// signedCell returns a cell that must start with the given two-byte signature.
+func (h *HiveFile) signedCell(offset uint32, signature string, minLength int) ([]byte, error) {
+	c, err := h.cell(offset)
+	if err != nil {
+		return nil, err
+	}
+	if len(c) < minLength || string(c[:2]) != signature {
+		return nil, fmt.Errorf("%w: cell 0x%X is not a valid %q cell", ErrCorruptHive, offset, signature)
+	}
+	return c, nil
+}
+
+func (h *HiveFile) keyNode(offset uint32) ([]byte, error) {
+	c, err := h.signedCell(offset, "nk", 0x4C)
+	if err != nil {
+		return nil, err
+	}
+	if 0x4C+int(binary.LittleEndian.Uint16(c[0x48:])) > len(c) {
+		return nil, fmt.Errorf("%w: key name at 0x%X runs past its cell", ErrCorruptHive, offset)
+	}
+	return c, nil
+}
+
+func (h *HiveFile) openKey(offset uint32) (*hiveKey, error) {
+	nk, err := h.keyNode(offset)
+	if err != nil {
+		return nil, err
+	}
+	return &hiveKey{h: h, nk: nk}, nil
+}
+
+// hiveKey is an open "nk" key node.
+type hiveKey struct {
+	h  *HiveFile
+	nk []byte
+}
+
+func (k *hiveKey) name() string {
+	flags := binary.LittleEndian.Uint16(k.nk[0x02:])
+	length := int(binary.LittleEndian.Uint16(k.nk[0x48:]))
+	return cellName(k.nk[0x4C:0x4C+length], flags&keyCompressedName != 0)
+}
+
+// subKeyOffsets walks the key's subkey index ("lf", "lh", "li" or "ri").
+func (k *hiveKey) subKeyOffsets() ([]uint32, error) {
+	if binary.LittleEndian.Uint32(k.nk[0x14:]) == 0 {
+		return nil, nil
+	}
+	return k.h.indexOffsets(binary.LittleEndian.Uint32(k.nk[0x1C:]), 0)
+}
+
+func (h *HiveFile) indexOffsets(offset uint32, depth int) ([]uint32, error) {
+	if depth > 8 {
+		return nil, fmt.Errorf("%w: subkey index at 0x%X nests too deeply", ErrCorruptHive, offset)
+	}
+	c, err := h.cell(offset)
+	if err != nil {
+		return nil, err
+	}
+	if len(c) < 4 {
+		return nil, fmt.Errorf("%w: subkey index at 0x%X is truncated", ErrCorruptHive, offset)
+	}
+	count := int(binary.LittleEndian.Uint16(c[2:]))
+
+	stride := 4
+	switch string(c[:2]) {
+	case "lf", "lh":
+		// Each entry is an offset followed by a name hint or hash
+		stride = 8
+	case "li", "ri":
+	default:
+		return nil, fmt.Errorf("%w: unknown subkey index %q at 0x%X", ErrCorruptHive, c[:2], offset)
+	}
+	if 4+count*stride > len(c) {
+		return nil, fmt.Errorf("%w: subkey index at 0x%X is truncated", ErrCorruptHive, offset)
+	}
+
+	var offsets []uint32
+	for i := 0; i < count; i++ {
+		entry := binary.LittleEndian.Uint32(c[4+i*stride:])
+		if string(c[:2]) != "ri" {
+			offsets = append(offsets, entry)
+			continue
+		}
+		sub, err := h.indexOffsets(entry, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		offsets = append(offsets, sub...)
+	}
+	return offsets, nil
+}
+
+func (k *hiveKey) OpenKey(path string) (Key, error) {
+	current := k
+	for _, name := range splitPath(path) {
+		offsets, err := current.subKeyOffsets()
+		if err != nil {
+			return nil, err
+		}
+		var next *hiveKey
+		for _, offset := range offsets {
+			sub, err := current.h.openKey(offset)
+			if err != nil {
+				return nil, err
+			}
+			if strings.EqualFold(sub.name(), name) {
+				next = sub
+				break
+			}
+		}
+		if next == nil {
+			return nil, ErrNotExist
+		}
+		current = next
+	}
+	return current, nil
+}
+
+func (k *hiveKey) SubKeyNames() ([]string, error) {
+	offsets, err := k.subKeyOffsets()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(offsets))
+	for _, offset := range offsets {
+		sub, err := k.h.openKey(offset)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, sub.name())
+	}
+	return names, nil
+}
+
+// valueCells returns the key's "vk" cells.
+func (k *hiveKey) valueCells() ([][]byte, error) {
+	count := int(binary.LittleEndian.Uint32(k.nk[0x24:]))
+	if count == 0 {
+		return nil, nil
+	}
+	list, err := k.h.cell(binary.LittleEndian.Uint32(k.nk[0x28:]))
+	if err != nil {
+		return nil, err
+	}
+	if count*4 > len(list) {
+		return nil, fmt.Errorf("%w: value list of %d entries is truncated", ErrCorruptHive, count)
+	}
+
+	cells := make([][]byte, 0, count)
+	for i := 0; i < count; i++ {
+		offset := binary.LittleEndian.Uint32(list[i*4:])
+		vk, err := k.h.signedCell(offset, "vk", 0x14)
+		if err != nil {
+			return nil, err
+		}
+		if 0x14+int(binary.LittleEndian.Uint16(vk[0x02:])) > len(vk) {
+			return nil, fmt.Errorf("%w: value name at 0x%X runs past its cell", ErrCorruptHive, offset)
+		}
+		cells = append(cells, vk)
+	}
+	return cells, nil
+}
+
+func valueName(vk []byte) string {
+	length := int(binary.LittleEndian.Uint16(vk[0x02:]))
+	flags := binary.LittleEndian.Uint16(vk[0x10:])
+	return cellName(vk[0x14:0x14+length], flags&valueCompressedName != 0)
+}
+
+func (k *hiveKey) ValueNames() ([]string, error) {
+	cells, err := k.valueCells()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(cells))
+	for _, vk := range cells {
+		names = append(names, valueName(vk))
+	}
+	return names, nil
+}
+
+func (k *hiveKey) Value(name string) (Value, error) {
+	cells, err := k.valueCells()
+	if err != nil {
+		return Value{}, err
+	}
+	for _, vk := range cells {
+		if strings.EqualFold(valueName(vk), name) {
+			data, err := k.h.valueData(vk)
+			if err != nil {
+				return Value{}, err
+			}
+			return Value{Type: binary.LittleEndian.Uint32(vk[0x0C:]), Data: data}, nil
+		}
+	}
+	return Value{}, ErrNotExist
+}
+
+// valueData returns a value's data, which is stored inline for up to four
+// bytes, in one cell, or split over "db" segments for large values.
+func (h *HiveFile) valueData(vk []byte) ([]byte, error) {
+	size := binary.LittleEndian.Uint32(vk[0x04:])
+	if size&0x80000000 != 0 {
+		size &^= 0x80000000
+		if size > 4 {
+			return nil, fmt.Errorf("%w: inline value data of %d bytes", ErrCorruptHive, size)
+		}
+		return vk[0x08 : 0x08+size], nil
+	}
+	if size == 0 {
+		return []byte{}, nil
+	}
+
+	c, err := h.cell(binary.LittleEndian.Uint32(vk[0x08:]))
+	if err != nil {
+		return nil, err
+	}
+	// Hives from version 1.4 on split values above one segment into "db" records
+	if size > bigDataSegmentSize && h.minorVersion >= 4 && len(c) >= 8 && string(c[:2]) == "db" {
+		return h.bigData(c, size)
+	}
+	if int(size) > len(c) {
+		return nil, fmt.Errorf("%w: value data of %d bytes is larger than its cell", ErrCorruptHive, size)
+	}
+	return c[:size], nil
+}
+
+func (h *HiveFile) bigData(db []byte, size uint32) ([]byte, error) {
+	count := int(binary.LittleEndian.Uint16(db[0x02:]))
+	list, err := h.cell(binary.LittleEndian.Uint32(db[0x04:]))
+	if err != nil {
+		return nil, err
+	}
+	if count*4 > len(list) {
+		return nil, fmt.Errorf("%w: big data segment list is truncated", ErrCorruptHive)
+	}
+
+	data := make([]byte, 0, size)
+	for i := 0; i < count && uint32(len(data)) < size; i++ {
+		segment, err := h.cell(binary.LittleEndian.Uint32(list[i*4:]))
+		if err != nil {
+			return nil, err
+		}
+		n := min(len(segment), bigDataSegmentSize, int(size)-len(data))
+		data = append(data, segment[:n]...)
+	}
+	if uint32(len(data)) < size {
+		return nil, fmt.Errorf("%w: big data holds %d of %d bytes", ErrCorruptHive, len(data), size)
+	}
+	return data, nil
+}
+
+func (k *hiveKey) StringValue(name string) (string, error) {
+	v, err := k.Value(name)
+	if err != nil {
+		return "", err
+	}
+	return v.AsString()
+}
+
+func (k *hiveKey) StringsValue(name string) ([]string, error) {
+	v, err := k.Value(name)
+	if err != nil {
+		return nil, err
+	}
+	return v.AsStrings()
+}
+
+func (k *hiveKey) BinaryValue(name string) ([]byte, error) {
+	v, err := k.Value(name)
+	if err != nil {
+		return nil, err
+	}
+	return v.AsBinary()
+}
+
+func (k *hiveKey) IntegerValue(name string) (uint64, error) {
+	v, err := k.Value(name)
+	if err != nil {
+		return 0, err
+	}
+	return v.AsInteger()
+}
+
+func (k *hiveKey) Close() error {
+	return nil
+}
+
+// cellName decodes a key or value name, stored as Latin-1 when compressed and
+// UTF-16LE otherwise.
+func cellName(b []byte, compressed bool) string {
+	if compressed {
+		runes := make([]rune, len(b))
+		for i, c := range b {
+			runes[i] = rune(c)
+		}
+		return string(runes)
+	}
+	return decodeUTF16(b)
+}
+
+// hiveMount places a hive's root at a key path.
+type hiveMount struct {
+	root   Root
+	prefix []string
+	hive   *HiveFile
+}
+
+// Hives is a registry assembled from hive files, each mounted where Windows
+// loads it (SOFTWARE at HKLM\SOFTWARE and so on).
+type Hives struct {
+	name   string
+	mounts []hiveMount
+}
+
+// hiveLocations lists the hive files LoadHiveDir looks for and where they mount.
+var hiveLocations = []struct {
+	file string
+	root Root
+	path string
+}{
+	{"SOFTWARE", LocalMachine, "SOFTWARE"},
+	{"SYSTEM", LocalMachine, "SYSTEM"},
+	{"SAM", LocalMachine, "SAM"},
+	{"SECURITY", LocalMachine, "SECURITY"},
+	{"NTUSER.DAT", CurrentUser, ""},
+}
+
+// NewHives returns a registry with nothing mounted.
+func NewHives(name string) *Hives {
+	return &Hives{name: name}
+}
+
+// LoadHiveDir mounts the hive files found in dir, such as a copy of
+// C:\Windows\System32\config. File names are matched case-insensitively.
+func LoadHiveDir(dir string) (*Hives, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	h := NewHives("hive:" + dir)
+	for _, location := range hiveLocations {
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.EqualFold(entry.Name(), location.file) {
+				continue
+			}
+			hive, err := OpenHiveFile(filepath.Join(dir, entry.Name()))
+			if err != nil {
+				return nil, err
+			}
+			h.Mount(location.root, location.path, hive)
+			break
+		}
+	}
+	if len(h.mounts) == 0 {
+		return nil, fmt.Errorf("no hive files (SOFTWARE, SYSTEM, SAM, SECURITY, NTUSER.DAT) found in %s", dir)
+	}
+	return h, nil
+}
+
+// Mount places hive's root key at path under root.
+func (h *Hives) Mount(root Root, path string, hive *HiveFile) {
+	h.mounts = append(h.mounts, hiveMount{root: root, prefix: splitPath(path), hive: hive})
+}
+
+func (h *Hives) Name() string {
+	return h.name
+}
+
+func (h *Hives) OpenKey(root Root, path string) (Key, error) {
+	names := splitPath(path)
+	for _, mount := range h.mounts {
+		if mount.root != root || !hasPathPrefix(names, mount.prefix) {
+			continue
+		}
+		k, err := mount.hive.Root()
+		if err != nil {
+			return nil, err
+		}
+		rest := names[len(mount.prefix):]
+		// CurrentControlSet is a link the kernel creates at boot; a SYSTEM
+		// hive file only holds the numbered sets and Select\Current
+		if len(rest) > 0 && strings.EqualFold(rest[0], "CurrentControlSet") && len(mount.prefix) == 1 && strings.EqualFold(mount.prefix[0], "SYSTEM") {
+			controlSet, err := currentControlSet(k)
+			if err != nil {
+				return nil, err
+			}
+			rest = append([]string{controlSet}, rest[1:]...)
+		}
+		return k.OpenKey(strings.Join(rest, `\`))
+	}
+	return nil, ErrNotExist
+}
+
+// currentControlSet resolves CurrentControlSet to ControlSetNNN from Select\Current.
+func currentControlSet(system Key) (string, error) {
+	sel, err := system.OpenKey("Select")
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve CurrentControlSet: %w", err)
+	}
+	current, err := sel.IntegerValue("Current")
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve CurrentControlSet: %w", err)
+	}
+	return fmt.Sprintf("ControlSet%03d", current), nil
+}
+
+func hasPathPrefix(names, prefix []string) bool {
+	if len(prefix) > len(names) {
+		return false
+	}
+	for i, p := range prefix {
+		if !strings.EqualFold(names[i], p) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,274 @@
+package reg
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// readHive returns a hive file from testdata/hives, laid out as `reg save` writes it.
+func readHive(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "hives", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// walkHive opens every key of a hive and reads every value, returning the first error.
+func walkHive(data []byte) error {
+	h, err := ParseHive("fixture", data)
+	if err != nil {
+		return err
+	}
+	root, err := h.Root()
+	if err != nil {
+		return err
+	}
+	return walkKey(root, 0)
+}
+
+func walkKey(k Key, depth int) error {
+	// A corrupted subkey offset can point back at an ancestor
+	if depth > 16 {
+		return nil
+	}
+	values, err := k.ValueNames()
+	if err != nil {
+		return err
+	}
+	for _, name := range values {
+		if _, err := k.Value(name); err != nil {
+			return err
+		}
+	}
+	names, err := k.SubKeyNames()
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		sub, err := k.OpenKey(name)
+		if err != nil {
+			return err
+		}
+		if err := walkKey(sub, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hiveCell is one cell of the hive bins, allocated or free.
+type hiveCell struct {
+	offset    int
+	size      int
+	allocated bool
+}
+
+// hiveCells lists the cells of a hive by walking the bins in order.
+func hiveCells(t *testing.T, data []byte) []hiveCell {
+	t.Helper()
+	var cells []hiveCell
+	bins := data[hiveBinsOffset:]
+	for bin := 0; bin < len(bins); {
+		binSize := int(binary.LittleEndian.Uint32(bins[bin+8:]))
+		for offset := bin + 0x20; offset < bin+binSize; {
+			size := int(int32(binary.LittleEndian.Uint32(bins[offset:])))
+			cell := hiveCell{offset: offset, size: size}
+			if size < 0 {
+				cell.size, cell.allocated = -size, true
+			}
+			if cell.size < 8 {
+				t.Fatalf("cell 0x%X has size %d", offset, cell.size)
+			}
+			cells = append(cells, cell)
+			offset += cell.size
+		}
+		bin += binSize
+	}
+	return cells
+}
+
+func TestHiveSubKeyLists(t *testing.T) {
+	h, err := ParseHive("SOFTWARE", readHive(t, "SOFTWARE"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := h.Root()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		// lf
+		{"", []string{"Classes", "Microsoft"}},
+		// lh
+		{"Microsoft", []string{"Cryptography", "Windows NT"}},
+		// li
+		{`Microsoft\Windows NT`, []string{"CurrentVersion"}},
+		// ri over two lh lists
+		{"Classes", []string{".bat", ".cmd", ".exe", ".txt", ".zip"}},
+		{`Microsoft\Cryptography`, []string{}},
+	}
+	for _, tt := range tests {
+		k, err := root.OpenKey(tt.path)
+		if err != nil {
+			t.Errorf("OpenKey(%q): %v", tt.path, err)
+			continue
+		}
+		if names, err := k.SubKeyNames(); err != nil || !slices.Equal(names, tt.want) {
+			t.Errorf("SubKeyNames(%q) = %q, %v; want %q", tt.path, names, err, tt.want)
+		}
+	}
+	// Names are case-insensitive
+	if _, err := root.OpenKey(`MICROSOFT\windows nt\currentversion`); err != nil {
+		t.Errorf("case-insensitive OpenKey: %v", err)
+	}
+	if _, err := root.OpenKey(`Classes\.doc`); !errors.Is(err, ErrNotExist) {
+		t.Errorf("missing key: err = %v, want ErrNotExist", err)
+	}
+}
+
+func TestHiveValues(t *testing.T) {
+	hives := NewHives("fixture")
+	h, err := ParseHive("SOFTWARE", readHive(t, "SOFTWARE"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	hives.Mount(LocalMachine, "SOFTWARE", h)
+
+	guid := openKey(t, hives, `HKLM\SOFTWARE\Microsoft\Cryptography`)
+	if got, err := guid.StringValue("MachineGuid"); err != nil || got != "6f1ed2c5-3f6a-4c0b-9d5e-2b7a8c1d4e3f" {
+		t.Errorf("MachineGuid = %q, %v", got, err)
+	}
+
+	k := openKey(t, hives, `HKLM\SOFTWARE\Microsoft\Windows NT\CurrentVersion`)
+	// Stored inline in the vk cell
+	if got, err := k.IntegerValue("UBR"); err != nil || got != 3803 {
+		t.Errorf(`IntegerValue("UBR") = %d, %v; want 3803`, got, err)
+	}
+	if got, err := k.IntegerValue("InstallTime"); err != nil || got != 0x01D7262FE2C6B787 {
+		t.Errorf(`IntegerValue("InstallTime") = 0x%X, %v`, got, err)
+	}
+	if got, err := k.StringValue("ProductId"); err != nil || got != "00330-80000-00000-AA478" {
+		t.Errorf(`StringValue("ProductId") = %q, %v`, got, err)
+	}
+	// Split over two "db" segments
+	big, err := k.BinaryValue("Größe")
+	if err != nil || len(big) != 20000 {
+		t.Fatalf(`BinaryValue("Größe") = %d bytes, %v; want 20000`, len(big), err)
+	}
+	for i, b := range big {
+		if b != byte(i*7) {
+			t.Fatalf("big data byte %d = 0x%02X, want 0x%02X", i, b, byte(i*7))
+		}
+	}
+	if got, err := k.BinaryValue("Empty"); err != nil || len(got) != 0 {
+		t.Errorf(`BinaryValue("Empty") = % X, %v`, got, err)
+	}
+	// A UTF-16 value name
+	if got, err := k.StringValue("名前"); err != nil || got != "unicode name" {
+		t.Errorf(`StringValue("名前") = %q, %v`, got, err)
+	}
+	want := []string{"ProductId", "UBR", "InstallTime", "Größe", "Empty", "名前"}
+	if names, err := k.ValueNames(); err != nil || !slices.Equal(names, want) {
+		t.Errorf("ValueNames = %q, %v; want %q", names, err, want)
+	}
+}
+
+func TestHiveCurrentControlSet(t *testing.T) {
+	dir := t.TempDir()
+	// LoadHiveDir matches file names case-insensitively
+	if err := os.WriteFile(filepath.Join(dir, "system"), readHive(t, "SYSTEM"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	hives, err := LoadHiveDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Select\Current is 2
+	k := openKey(t, hives, `HKLM\SYSTEM\CurrentControlSet\Control\ComputerName\ComputerName`)
+	if got, err := k.StringValue("ComputerName"); err != nil || got != "DESKTOP-TEST" {
+		t.Errorf("ComputerName through CurrentControlSet = %q, %v; want DESKTOP-TEST", got, err)
+	}
+	k = openKey(t, hives, `HKLM\SYSTEM\ControlSet001\Control\ComputerName\ComputerName`)
+	if got, err := k.StringValue("ComputerName"); err != nil || got != "STALE-NAME" {
+		t.Errorf("ComputerName in ControlSet001 = %q, %v; want STALE-NAME", got, err)
+	}
+	if _, err := hives.OpenKey(LocalMachine, `SOFTWARE\Microsoft`); !errors.Is(err, ErrNotExist) {
+		t.Errorf("unmounted SOFTWARE: err = %v, want ErrNotExist", err)
+	}
+	if _, err := LoadHiveDir(t.TempDir()); err == nil {
+		t.Error("LoadHiveDir on an empty directory returned no error")
+	}
+}
+
+func TestHiveTruncated(t *testing.T) {
+	for _, name := range []string{"SOFTWARE", "SYSTEM"} {
+		data := readHive(t, name)
+		if err := walkHive(data); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		// Every allocated cell is referenced, so cutting the file anywhere before the
+		// end of the last one loses something
+		end := hiveBinsOffset
+		for _, c := range hiveCells(t, data) {
+			if c.allocated {
+				end = hiveBinsOffset + c.offset + c.size
+			}
+		}
+		for n := 0; n < end; n += 7 {
+			if err := walkHive(data[:n]); !errors.Is(err, ErrCorruptHive) {
+				t.Errorf("%s cut to %d bytes: err = %v, want ErrCorruptHive", name, n, err)
+			}
+		}
+	}
+}
+
+func TestHiveCorrupted(t *testing.T) {
+	data := readHive(t, "SOFTWARE")
+
+	// Each structure with a signature is rejected once the signature is gone
+	for _, c := range hiveCells(t, data) {
+		offset := hiveBinsOffset + c.offset + 4
+		signature := string(data[offset : offset+2])
+		if !c.allocated || !slices.Contains([]string{"nk", "vk", "lf", "lh", "li", "ri", "db"}, signature) {
+			continue
+		}
+		corrupt := slices.Clone(data)
+		copy(corrupt[offset:], "zz")
+		if err := walkHive(corrupt); !errors.Is(err, ErrCorruptHive) {
+			t.Errorf("%q cell at 0x%X clobbered: err = %v, want ErrCorruptHive", signature, c.offset, err)
+		}
+	}
+
+	corrupt := slices.Clone(data)
+	binary.LittleEndian.PutUint32(corrupt[0x24:], 0xFFFFFFF0)
+	if _, err := ParseHive("fixture", corrupt); !errors.Is(err, ErrCorruptHive) {
+		t.Errorf("root cell outside the hive: err = %v, want ErrCorruptHive", err)
+	}
+	corrupt = slices.Clone(data)
+	copy(corrupt, "regX")
+	if _, err := ParseHive("fixture", corrupt); !errors.Is(err, ErrCorruptHive) {
+		t.Errorf("bad base block signature: err = %v, want ErrCorruptHive", err)
+	}
+
+	// Flipping any byte of the bins may change what is read but must not panic
+	for i := hiveBinsOffset; i < len(data); i++ {
+		data[i] ^= 0xFF
+		err := walkHive(data)
+		data[i] ^= 0xFF
+		if err != nil && !errors.Is(err, ErrCorruptHive) && !errors.Is(err, ErrNotExist) {
+			t.Errorf("byte 0x%X flipped: err = %v, want ErrCorruptHive or ErrNotExist", i, err)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+//go:build !windows
+
+package reg
+
+import (
+	"errors"
+)
+
+// errNoLiveRegistry is returned by the live backends off Windows; use a hive
+// directory or a .reg file instead.
+var errNoLiveRegistry = errors.New("the live registry is only available on Windows")
+
+// Live reads the running machine's registry, which only exists on Windows.
+type Live struct {
+	View View
+}
+
+func (Live) Name() string {
+	return "live"
+}
+
+func (Live) OpenKey(root Root, path string) (Key, error) {
+	return nil, errNoLiveRegistry
+}
+
+// NT reads the running machine's registry through ntdll, which only exists on Windows.
+type NT struct{}
+
+func (NT) Name() string {
+	return "ntdll"
+}
+
+func (NT) OpenKey(root Root, path string) (Key, error) {
+	return nil, errNoLiveRegistry
+}
@@ -0,0 +1,114 @@
+package reg
+
+import (
+	"errors"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+var liveRoots = map[Root]registry.Key{
+	ClassesRoot:  registry.CLASSES_ROOT,
+	CurrentUser:  registry.CURRENT_USER,
+	LocalMachine: registry.LOCAL_MACHINE,
+	Users:        registry.USERS,
+}
+
+// Live reads the running machine's registry through advapi32, in View.
+type Live struct {
+	View View
+}
+
+type liveKey struct {
+	k      registry.Key
+	access uint32
+}
+
+func (l Live) Name() string {
+	if l.View != DefaultView {
+		return "live (" + l.View.String() + ")"
+	}
+	return "live"
+}
+
+func (l Live) OpenKey(root Root, path string) (Key, error) {
+	access := uint32(registry.READ) | uint32(l.View)
+	k, err := registry.OpenKey(liveRoots[root], path, access)
+	if err != nil {
+		return nil, liveError(err)
+	}
+	return liveKey{k, access}, nil
+}
+
+func (k liveKey) OpenKey(path string) (Key, error) {
+	// The view flag has to be repeated on every open below a redirected key
+	sub, err := registry.OpenKey(k.k, path, k.access)
+	if err != nil {
+		return nil, liveError(err)
+	}
+	return liveKey{sub, k.access}, nil
+}
+
+func (k liveKey) SubKeyNames() ([]string, error) {
+	names, err := k.k.ReadSubKeyNames(-1)
+	return names, liveError(err)
+}
+
+func (k liveKey) ValueNames() ([]string, error) {
+	names, err := k.k.ReadValueNames(-1)
+	return names, liveError(err)
+}
+
+func (k liveKey) StringValue(name string) (string, error) {
+	s, _, err := k.k.GetStringValue(name)
+	return s, liveError(err)
+}
+
+func (k liveKey) StringsValue(name string) ([]string, error) {
+	ss, _, err := k.k.GetStringsValue(name)
+	return ss, liveError(err)
+}
+
+func (k liveKey) BinaryValue(name string) ([]byte, error) {
+	b, _, err := k.k.GetBinaryValue(name)
+	return b, liveError(err)
+}
+
+func (k liveKey) IntegerValue(name string) (uint64, error) {
+	n, _, err := k.k.GetIntegerValue(name)
+	return n, liveError(err)
+}
+
+func (k liveKey) Value(name string) (Value, error) {
+	n, typ, err := k.k.GetValue(name, nil)
+	for err == nil {
+		if n == 0 {
+			return Value{Type: typ, Data: []byte{}}, nil
+		}
+		buf := make([]byte, n)
+		var read int
+		read, typ, err = k.k.GetValue(name, buf)
+		if err == nil {
+			return Value{Type: typ, Data: buf[:read]}, nil
+		}
+		// The value grew between the size query and the read
+		if errors.Is(err, registry.ErrShortBuffer) {
+			n, err = read, nil
+		}
+	}
+	return Value{}, liveError(err)
+}
+
+func (k liveKey) Close() error {
+	return k.k.Close()
+}
+
+// liveError maps the x/sys registry errors onto this package's.
+func liveError(err error) error {
+	switch {
+	case errors.Is(err, registry.ErrNotExist):
+		return ErrNotExist
+	case errors.Is(err, registry.ErrUnexpectedType):
+		return ErrUnexpectedType
+	}
+	return err
+}
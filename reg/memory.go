@@ -0,0 +1,179 @@
+package reg
+
+import (
+	"sort"
+	"strings"
+)
+
+// Memory is an in-memory registry, usually loaded from a .reg fixture with
+// LoadRegFile or ParseRegFile.
+type Memory struct {
+	name  string
+	roots map[Root]*memoryKey
+}
+
+type memoryKey struct {
+	name    string
+	subKeys map[string]*memoryKey
+	values  map[string]memoryValue
+}
+
+type memoryValue struct {
+	name  string
+	value Value
+}
+
+// NewMemory returns an empty in-memory registry.
+func NewMemory(name string) *Memory {
+	return &Memory{name: name, roots: make(map[Root]*memoryKey)}
+}
+
+func newMemoryKey(name string) *memoryKey {
+	return &memoryKey{name: name, subKeys: make(map[string]*memoryKey), values: make(map[string]memoryValue)}
+}
+
+func (m *Memory) Name() string {
+	return m.name
+}
+
+func (m *Memory) OpenKey(root Root, path string) (Key, error) {
+	k, ok := m.roots[root]
+	if !ok {
+		return nil, ErrNotExist
+	}
+	return k.OpenKey(path)
+}
+
+// createKey returns the key at path, creating it and its parents as needed.
+func (m *Memory) createKey(root Root, path string) *memoryKey {
+	k, ok := m.roots[root]
+	if !ok {
+		k = newMemoryKey(string(root))
+		m.roots[root] = k
+	}
+	for _, name := range splitPath(path) {
+		sub, ok := k.subKeys[strings.ToLower(name)]
+		if !ok {
+			sub = newMemoryKey(name)
+			k.subKeys[strings.ToLower(name)] = sub
+		}
+		k = sub
+	}
+	return k
+}
+
+// DeleteKey removes the key at path and everything below it.
+func (m *Memory) DeleteKey(root Root, path string) {
+	names := splitPath(path)
+	k, ok := m.roots[root]
+	if !ok {
+		return
+	}
+	if len(names) == 0 {
+		delete(m.roots, root)
+		return
+	}
+	for _, name := range names[:len(names)-1] {
+		if k, ok = k.subKeys[strings.ToLower(name)]; !ok {
+			return
+		}
+	}
+	delete(k.subKeys, strings.ToLower(names[len(names)-1]))
+}
+
+// SetValue stores a value, creating the key as needed. The default value is named "".
+func (m *Memory) SetValue(root Root, path, name string, v Value) {
+	k := m.createKey(root, path)
+	k.values[strings.ToLower(name)] = memoryValue{name: name, value: v}
+}
+
+// DeleteValue removes a value if it exists.
+func (m *Memory) DeleteValue(root Root, path, name string) {
+	if k, err := m.OpenKey(root, path); err == nil {
+		delete(k.(*memoryKey).values, strings.ToLower(name))
+	}
+}
+
+func (k *memoryKey) OpenKey(path string) (Key, error) {
+	for _, name := range splitPath(path) {
+		sub, ok := k.subKeys[strings.ToLower(name)]
+		if !ok {
+			return nil, ErrNotExist
+		}
+		k = sub
+	}
+	return k, nil
+}
+
+func (k *memoryKey) SubKeyNames() ([]string, error) {
+	names := make([]string, 0, len(k.subKeys))
+	for _, sub := range k.subKeys {
+		names = append(names, sub.name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (k *memoryKey) ValueNames() ([]string, error) {
+	names := make([]string, 0, len(k.values))
+	for _, v := range k.values {
+		names = append(names, v.name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (k *memoryKey) Value(name string) (Value, error) {
+	v, ok := k.values[strings.ToLower(name)]
+	if !ok {
+		return Value{}, ErrNotExist
+	}
+	return v.value, nil
+}
+
+func (k *memoryKey) StringValue(name string) (string, error) {
+	v, err := k.Value(name)
+	if err != nil {
+		return "", err
+	}
+	return v.AsString()
+}
+
+func (k *memoryKey) StringsValue(name string) ([]string, error) {
+	v, err := k.Value(name)
+	if err != nil {
+		return nil, err
+	}
+	return v.AsStrings()
+}
+
+func (k *memoryKey) BinaryValue(name string) ([]byte, error) {
+	v, err := k.Value(name)
+	if err != nil {
+		return nil, err
+	}
+	return v.AsBinary()
+}
+
+func (k *memoryKey) IntegerValue(name string) (uint64, error) {
+	v, err := k.Value(name)
+	if err != nil {
+		return 0, err
+	}
+	return v.AsInteger()
+}
+
+func (k *memoryKey) Close() error {
+	return nil
+}
+
+// splitPath splits a backslash-separated key path, ignoring empty components.
+func splitPath(path string) []string {
+	var names []string
+	for _, name := range strings.Split(path, `\`) {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
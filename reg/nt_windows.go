@@ -0,0 +1,243 @@
+package reg
+
+import (
+	"encoding/binary"
+	"errors"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	ntdll                   = syscall.NewLazyDLL("ntdll.dll")
+	procNtOpenKeyEx         = ntdll.NewProc("NtOpenKeyEx")
+	procNtQueryValueKey     = ntdll.NewProc("NtQueryValueKey")
+	procNtEnumerateKey      = ntdll.NewProc("NtEnumerateKey")
+	procNtEnumerateValueKey = ntdll.NewProc("NtEnumerateValueKey")
+	procNtClose             = ntdll.NewProc("NtClose")
+)
+
+// Information classes, with the offsets of the fields read from their structures.
+const (
+	keyBasicInformation        = 0 // KEY_BASIC_INFORMATION: name length at 12, name at 16
+	keyValueBasicInformation   = 0 // KEY_VALUE_BASIC_INFORMATION: name length at 8, name at 12
+	keyValuePartialInformation = 2 // KEY_VALUE_PARTIAL_INFORMATION: type at 4, length at 8, data at 12
+)
+
+const (
+	ntKeyRead           = 0x20019 // KEY_READ
+	ntInitialBufferSize = 256
+
+	statusObjectNameInvalid   windows.NTStatus = 0xC0000033
+	statusObjectPathNotFound  windows.NTStatus = 0xC000003A
+	statusObjectPathSyntaxBad windows.NTStatus = 0xC000003B
+)
+
+// NT reads the running machine's registry through ntdll's NtOpenKeyEx and
+// NtQueryValueKey, below the advapi32 and kernelbase functions Live goes through.
+type NT struct{}
+
+type ntKey struct {
+	handle windows.Handle
+}
+
+func (NT) Name() string {
+	return "ntdll"
+}
+
+func (NT) OpenKey(root Root, path string) (Key, error) {
+	rootPath, err := ntRootPath(root)
+	if err != nil {
+		return nil, err
+	}
+	if path != "" {
+		rootPath += `\` + path
+	}
+	return ntOpenKey(0, rootPath)
+}
+
+// ntRootPath maps a predefined key to its object path under \Registry.
+func ntRootPath(root Root) (string, error) {
+	switch root {
+	case LocalMachine:
+		return `\Registry\Machine`, nil
+	case Users:
+		return `\Registry\User`, nil
+	case CurrentUser:
+		user, err := windows.GetCurrentProcessToken().GetTokenUser()
+		if err != nil {
+			return "", err
+		}
+		return `\Registry\User\` + user.User.Sid.String(), nil
+	case ClassesRoot:
+		// HKCR merges HKCU\Software\Classes over this machine half, which is all
+		// NT reads, so per-user class registrations are not seen here
+		return `\Registry\Machine\SOFTWARE\Classes`, nil
+	}
+	return "", errors.New("unknown registry root " + string(root))
+}
+
+func ntOpenKey(parent windows.Handle, path string) (Key, error) {
+	name, err := windows.NewNTUnicodeString(path)
+	if err != nil {
+		return nil, err
+	}
+	attributes := windows.OBJECT_ATTRIBUTES{
+		RootDirectory: parent,
+		ObjectName:    name,
+		Attributes:    windows.OBJ_CASE_INSENSITIVE,
+	}
+	attributes.Length = uint32(unsafe.Sizeof(attributes))
+
+	var handle windows.Handle
+	status, _, _ := procNtOpenKeyEx.Call(
+		uintptr(unsafe.Pointer(&handle)),
+		uintptr(ntKeyRead),
+		uintptr(unsafe.Pointer(&attributes)),
+		0, // OpenOptions
+	)
+	if err := ntError(status); err != nil {
+		return nil, err
+	}
+	return ntKey{handle}, nil
+}
+
+func (k ntKey) OpenKey(path string) (Key, error) {
+	return ntOpenKey(k.handle, path)
+}
+
+// query calls an NtQuery*/NtEnumerate* function taking (handle, arg, class, buffer,
+// length, &resultLength) and grows the buffer until the result fits.
+func (k ntKey) query(proc *syscall.LazyProc, arg uintptr, class uintptr) ([]byte, error) {
+	buf := make([]byte, ntInitialBufferSize)
+	for {
+		var resultLength uint32
+		status, _, _ := proc.Call(
+			uintptr(k.handle),
+			arg,
+			class,
+			uintptr(unsafe.Pointer(&buf[0])),
+			uintptr(len(buf)),
+			uintptr(unsafe.Pointer(&resultLength)),
+		)
+		switch windows.NTStatus(status) {
+		case windows.STATUS_BUFFER_OVERFLOW, windows.STATUS_BUFFER_TOO_SMALL:
+			buf = make([]byte, max(int(resultLength), 2*len(buf)))
+			continue
+		}
+		if err := ntError(status); err != nil {
+			return nil, err
+		}
+		return buf[:resultLength], nil
+	}
+}
+
+// enumerateNames lists subkey or value names until STATUS_NO_MORE_ENTRIES.
+func (k ntKey) enumerateNames(proc *syscall.LazyProc, class uintptr, lengthOffset, nameOffset int) ([]string, error) {
+	var names []string
+	for index := uintptr(0); ; index++ {
+		info, err := k.query(proc, index, class)
+		if errors.Is(err, errNoMoreEntries) {
+			return names, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(info) < nameOffset {
+			return nil, errors.New("registry enumeration returned a truncated record")
+		}
+		length := int(binary.LittleEndian.Uint32(info[lengthOffset:]))
+		if nameOffset+length > len(info) {
+			return nil, errors.New("registry enumeration returned a truncated name")
+		}
+		names = append(names, decodeUTF16(info[nameOffset:nameOffset+length]))
+	}
+}
+
+func (k ntKey) SubKeyNames() ([]string, error) {
+	return k.enumerateNames(procNtEnumerateKey, keyBasicInformation, 12, 16)
+}
+
+func (k ntKey) ValueNames() ([]string, error) {
+	return k.enumerateNames(procNtEnumerateValueKey, keyValueBasicInformation, 8, 12)
+}
+
+func (k ntKey) Value(name string) (Value, error) {
+	valueName, err := windows.NewNTUnicodeString(name)
+	if err != nil {
+		return Value{}, err
+	}
+	info, err := k.query(procNtQueryValueKey, uintptr(unsafe.Pointer(valueName)), keyValuePartialInformation)
+	if err != nil {
+		return Value{}, err
+	}
+	if len(info) < 12 {
+		return Value{}, errors.New("NtQueryValueKey returned a truncated record")
+	}
+	length := int(binary.LittleEndian.Uint32(info[8:]))
+	if 12+length > len(info) {
+		return Value{}, errors.New("NtQueryValueKey returned truncated data")
+	}
+	data := make([]byte, length)
+	copy(data, info[12:])
+	return Value{Type: binary.LittleEndian.Uint32(info[4:]), Data: data}, nil
+}
+
+func (k ntKey) StringValue(name string) (string, error) {
+	v, err := k.Value(name)
+	if err != nil {
+		return "", err
+	}
+	return v.AsString()
+}
+
+func (k ntKey) StringsValue(name string) ([]string, error) {
+	v, err := k.Value(name)
+	if err != nil {
+		return nil, err
+	}
+	return v.AsStrings()
+}
+
+func (k ntKey) BinaryValue(name string) ([]byte, error) {
+	v, err := k.Value(name)
+	if err != nil {
+		return nil, err
+	}
+	return v.AsBinary()
+}
+
+func (k ntKey) IntegerValue(name string) (uint64, error) {
+	v, err := k.Value(name)
+	if err != nil {
+		return 0, err
+	}
+	return v.AsInteger()
+}
+
+func (k ntKey) Close() error {
+	status, _, _ := procNtClose.Call(uintptr(k.handle))
+	return ntError(status)
+}
+
+// errNoMoreEntries ends an NtEnumerate* loop.
+var errNoMoreEntries = errors.New("no more entries")
+
+// ntError maps an NTSTATUS to nil on success or this package's errors.
+func ntError(status uintptr) error {
+	switch s := windows.NTStatus(status); {
+	case s == 0:
+		return nil
+	case s == windows.STATUS_OBJECT_NAME_NOT_FOUND || s == statusObjectPathNotFound:
+		return ErrNotExist
+	case s == windows.STATUS_NO_MORE_ENTRIES:
+		return errNoMoreEntries
+	case s == windows.STATUS_OBJECT_TYPE_MISMATCH:
+		return ErrUnexpectedType
+	case s == statusObjectNameInvalid || s == statusObjectPathSyntaxBad:
+		return errors.New("invalid registry path: " + s.Error())
+	default:
+		return s
+	}
+}
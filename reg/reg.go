@@ -0,0 +1,217 @@
+// Package reg is a small registry-reader interface with interchangeable
+// backends, so the registry-based collectors can run against the live
+// registry or against fixtures on any OS.
+package reg
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+)
+
+// Root is a predefined top-level key.
+type Root string
+
+const (
+	ClassesRoot  Root = "HKEY_CLASSES_ROOT"
+	CurrentUser  Root = "HKEY_CURRENT_USER"
+	LocalMachine Root = "HKEY_LOCAL_MACHINE"
+	Users        Root = "HKEY_USERS"
+)
+
+// rootAliases maps the abbreviated root names to the full ones.
+var rootAliases = map[string]Root{
+	"HKCR": ClassesRoot,
+	"HKCU": CurrentUser,
+	"HKLM": LocalMachine,
+	"HKU":  Users,
+}
+
+// View selects the 32-bit or 64-bit registry view of a 64-bit system, using
+// the KEY_WOW64_32KEY and KEY_WOW64_64KEY access flag values.
+type View uint32
+
+const (
+	// DefaultView is the view of the running process.
+	DefaultView View = 0
+	View64      View = 0x0100
+	View32      View = 0x0200
+)
+
+func (v View) String() string {
+	switch v {
+	case View32:
+		return "32-bit"
+	case View64:
+		return "64-bit"
+	}
+	return "default"
+}
+
+// Value types, as the REG_* constants number them.
+const (
+	TypeNone             = 0
+	TypeString           = 1
+	TypeExpandString     = 2
+	TypeBinary           = 3
+	TypeDWord            = 4
+	TypeDWordBigEndian   = 5
+	TypeLink             = 6
+	TypeMultiString      = 7
+	TypeResourceList     = 8
+	TypeFullResourceDesc = 9
+	TypeResourceReqList  = 10
+	TypeQWord            = 11
+)
+
+var (
+	// ErrNotExist is returned when a key or value does not exist.
+	ErrNotExist = errors.New("registry key or value does not exist")
+	// ErrUnexpectedType is returned when a value is not of the type requested.
+	ErrUnexpectedType = errors.New("unexpected registry value type")
+)
+
+// Registry opens keys in one registry backend.
+type Registry interface {
+	// Name identifies the backend in output, e.g. "live" or a fixture path.
+	Name() string
+	OpenKey(root Root, path string) (Key, error)
+}
+
+// Key is an open registry key. Key and value names are case-insensitive.
+type Key interface {
+	// OpenKey opens a subkey by its path relative to this key.
+	OpenKey(path string) (Key, error)
+	SubKeyNames() ([]string, error)
+	ValueNames() ([]string, error)
+	// StringValue reads a REG_SZ or REG_EXPAND_SZ value, unexpanded.
+	StringValue(name string) (string, error)
+	StringsValue(name string) ([]string, error)
+	BinaryValue(name string) ([]byte, error)
+	// IntegerValue reads a REG_DWORD or REG_QWORD value.
+	IntegerValue(name string) (uint64, error)
+	// Value reads a value of any type as stored.
+	Value(name string) (Value, error)
+	Close() error
+}
+
+// ParseRoot splits a full key path such as `HKEY_LOCAL_MACHINE\SOFTWARE\Foo`
+// or `HKLM\SOFTWARE\Foo` into its root and the path below it.
+func ParseRoot(path string) (Root, string, error) {
+	name, rest, _ := strings.Cut(path, `\`)
+	name = strings.ToUpper(name)
+	if root, ok := rootAliases[name]; ok {
+		return root, rest, nil
+	}
+	switch root := Root(name); root {
+	case ClassesRoot, CurrentUser, LocalMachine, Users:
+		return root, rest, nil
+	}
+	return "", "", fmt.Errorf("unknown registry root %q", name)
+}
+
+// Value is a value as the registry stores it: a REG_* type and raw data.
+// Backends that see raw data decode it with the As methods.
+type Value struct {
+	Type uint32
+	Data []byte
+}
+
+// String formats the value for display: strings as-is, integers in decimal
+// and hex, and binary data as hex, cut short after 32 bytes.
+func (v Value) String() string {
+	switch v.Type {
+	case TypeString, TypeExpandString:
+		s, _ := v.AsString()
+		return s
+	case TypeMultiString:
+		ss, _ := v.AsStrings()
+		return strings.Join(ss, ", ")
+	case TypeDWord, TypeQWord:
+		if n, err := v.AsInteger(); err == nil {
+			return fmt.Sprintf("%d (0x%X)", n, n)
+		}
+	}
+	if len(v.Data) > 32 {
+		return fmt.Sprintf("%X... (%d bytes)", v.Data[:32], len(v.Data))
+	}
+	return fmt.Sprintf("%X", v.Data)
+}
+
+// AsString decodes a REG_SZ or REG_EXPAND_SZ value.
+func (v Value) AsString() (string, error) {
+	if v.Type != TypeString && v.Type != TypeExpandString {
+		return "", ErrUnexpectedType
+	}
+	s := decodeUTF16(v.Data)
+	if i := strings.IndexByte(s, 0); i >= 0 {
+		s = s[:i]
+	}
+	return s, nil
+}
+
+// AsStrings decodes a REG_MULTI_SZ value.
+func (v Value) AsStrings() ([]string, error) {
+	if v.Type != TypeMultiString {
+		return nil, ErrUnexpectedType
+	}
+	s := strings.TrimRight(decodeUTF16(v.Data), "\x00")
+	if s == "" {
+		return []string{}, nil
+	}
+	return strings.Split(s, "\x00"), nil
+}
+
+// AsBinary returns the data of a REG_BINARY value.
+func (v Value) AsBinary() ([]byte, error) {
+	if v.Type != TypeBinary {
+		return nil, ErrUnexpectedType
+	}
+	return v.Data, nil
+}
+
+// AsInteger decodes a REG_DWORD or REG_QWORD value.
+func (v Value) AsInteger() (uint64, error) {
+	switch {
+	case v.Type == TypeDWord && len(v.Data) >= 4:
+		return uint64(binary.LittleEndian.Uint32(v.Data)), nil
+	case v.Type == TypeQWord && len(v.Data) >= 8:
+		return binary.LittleEndian.Uint64(v.Data), nil
+	case v.Type == TypeDWord || v.Type == TypeQWord:
+		return 0, fmt.Errorf("registry integer value is %d bytes", len(v.Data))
+	}
+	return 0, ErrUnexpectedType
+}
+
+// StringData encodes s as REG_SZ data: UTF-16LE with a terminator.
+func StringData(s string) []byte {
+	return encodeUTF16(s + "\x00")
+}
+
+// StringsData encodes ss as REG_MULTI_SZ data.
+func StringsData(ss []string) []byte {
+	var s string
+	for _, item := range ss {
+		s += item + "\x00"
+	}
+	return encodeUTF16(s + "\x00")
+}
+
+func decodeUTF16(b []byte) string {
+	chars := make([]uint16, len(b)/2)
+	for i := range chars {
+		chars[i] = binary.LittleEndian.Uint16(b[2*i:])
+	}
+	return string(utf16.Decode(chars))
+}
+
+func encodeUTF16(s string) []byte {
+	chars := utf16.Encode([]rune(s))
+	b := make([]byte, 2*len(chars))
+	for i, c := range chars {
+		binary.LittleEndian.PutUint16(b[2*i:], c)
+	}
+	return b
+}
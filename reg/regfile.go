@@ -0,0 +1,226 @@
+package reg
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// regFileHeaders are the first lines regedit writes to exported files.
+var regFileHeaders = []string{"Windows Registry Editor Version 5.00", "REGEDIT4"}
+
+// LoadRegFile reads a .reg file exported by regedit into an in-memory registry.
+func LoadRegFile(path string) (*Memory, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	m := NewMemory(path)
+	if err := m.ParseRegFile(data); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return m, nil
+}
+
+// ParseRegFile applies the contents of a .reg file to m. Both the UTF-16LE
+// files regedit exports and UTF-8 files are accepted. Key and value deletions
+// ("[-key]" and "name"=-) are applied too.
+func (m *Memory) ParseRegFile(data []byte) error {
+	text := decodeRegFile(data)
+	lines := joinContinuations(strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n"))
+
+	header := false
+	// REGEDIT4 files hold hex(2) and hex(7) data as ANSI rather than UTF-16
+	ansi := false
+	var root Root
+	var path string
+	inKey := false
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if !header {
+			for _, h := range regFileHeaders {
+				header = header || line == h
+			}
+			ansi = line == "REGEDIT4"
+			if !header {
+				return fmt.Errorf("line %d: missing .reg header", i+1)
+			}
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return fmt.Errorf("line %d: unterminated key name", i+1)
+			}
+			name := line[1 : len(line)-1]
+			deleteKey := strings.HasPrefix(name, "-")
+			var err error
+			if root, path, err = ParseRoot(strings.TrimPrefix(name, "-")); err != nil {
+				return fmt.Errorf("line %d: %w", i+1, err)
+			}
+			if deleteKey {
+				m.DeleteKey(root, path)
+				inKey = false
+				continue
+			}
+			m.createKey(root, path)
+			inKey = true
+			continue
+		}
+
+		if !inKey {
+			return fmt.Errorf("line %d: value outside of a key", i+1)
+		}
+		name, rest, err := parseValueName(line)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", i+1, err)
+		}
+		if rest == "-" {
+			m.DeleteValue(root, path, name)
+			continue
+		}
+		v, err := parseValueData(rest, ansi)
+		if err != nil {
+			return fmt.Errorf("line %d: value %q: %w", i+1, name, err)
+		}
+		m.SetValue(root, path, name, v)
+	}
+	if !header {
+		return fmt.Errorf("missing .reg header")
+	}
+	return nil
+}
+
+// decodeRegFile turns a UTF-16LE (with BOM) or UTF-8 .reg file into a string.
+func decodeRegFile(data []byte) string {
+	if bytes.HasPrefix(data, []byte{0xFF, 0xFE}) {
+		return decodeUTF16(data[2:])
+	}
+	return string(bytes.TrimPrefix(data, []byte{0xEF, 0xBB, 0xBF}))
+}
+
+// joinContinuations merges the hex data lines regedit wraps with a trailing backslash.
+func joinContinuations(lines []string) []string {
+	var joined []string
+	current := ""
+	for _, line := range lines {
+		if current != "" {
+			line = strings.TrimSpace(line)
+		}
+		line = strings.TrimRight(line, " \t")
+		if strings.HasSuffix(line, `\`) && !strings.HasPrefix(strings.TrimSpace(line), "[") {
+			current += strings.TrimSuffix(line, `\`)
+			continue
+		}
+		joined = append(joined, current+line)
+		current = ""
+	}
+	if current != "" {
+		joined = append(joined, current)
+	}
+	return joined
+}
+
+// parseValueName splits `"name"=data` or `@=data` into the unescaped name and the data.
+func parseValueName(line string) (string, string, error) {
+	if strings.HasPrefix(line, "@=") {
+		return "", line[2:], nil
+	}
+	if !strings.HasPrefix(line, `"`) {
+		return "", "", fmt.Errorf("expected a quoted value name")
+	}
+	name, rest, err := parseQuoted(line)
+	if err != nil {
+		return "", "", err
+	}
+	if !strings.HasPrefix(rest, "=") {
+		return "", "", fmt.Errorf("expected '=' after value name")
+	}
+	return name, rest[1:], nil
+}
+
+// parseQuoted reads a quoted string with \\ and \" escapes from the start of s.
+func parseQuoted(s string) (string, string, error) {
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if i+1 < len(s) {
+				i++
+				b.WriteByte(s[i])
+			}
+		case '"':
+			return b.String(), s[i+1:], nil
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return "", "", fmt.Errorf("unterminated string")
+}
+
+// parseValueData decodes the data after '=': "string", dword:, hex: or hex(n):. With
+// ansi set, REG_EXPAND_SZ and REG_MULTI_SZ hex data is widened to UTF-16.
+func parseValueData(data string, ansi bool) (Value, error) {
+	switch {
+	case strings.HasPrefix(data, `"`):
+		s, rest, err := parseQuoted(data)
+		if err != nil {
+			return Value{}, err
+		}
+		if strings.TrimSpace(rest) != "" {
+			return Value{}, fmt.Errorf("unexpected data after string")
+		}
+		return Value{Type: TypeString, Data: StringData(s)}, nil
+
+	case strings.HasPrefix(data, "dword:"):
+		n, err := strconv.ParseUint(strings.TrimPrefix(data, "dword:"), 16, 32)
+		if err != nil {
+			return Value{}, err
+		}
+		b := make([]byte, 4)
+		binary.LittleEndian.PutUint32(b, uint32(n))
+		return Value{Type: TypeDWord, Data: b}, nil
+
+	case strings.HasPrefix(data, "hex"):
+		typ := uint64(TypeBinary)
+		spec, hexData, ok := strings.Cut(data, ":")
+		if !ok {
+			return Value{}, fmt.Errorf("missing ':' after hex")
+		}
+		if spec != "hex" {
+			if !strings.HasPrefix(spec, "hex(") || !strings.HasSuffix(spec, ")") {
+				return Value{}, fmt.Errorf("malformed type %q", spec)
+			}
+			var err error
+			if typ, err = strconv.ParseUint(spec[4:len(spec)-1], 16, 32); err != nil {
+				return Value{}, err
+			}
+		}
+		b, err := hex.DecodeString(strings.NewReplacer(",", "", " ", "", "\t", "").Replace(hexData))
+		if err != nil {
+			return Value{}, err
+		}
+		if ansi && (typ == TypeExpandString || typ == TypeMultiString) {
+			b = widenANSI(b)
+		}
+		return Value{Type: uint32(typ), Data: b}, nil
+	}
+	return Value{}, fmt.Errorf("unrecognized data %q", data)
+}
+
+// widenANSI converts single-byte string data to UTF-16LE. The code page of the
+// machine that exported the file is unknown, so bytes are taken as Latin-1.
+func widenANSI(b []byte) []byte {
+	wide := make([]byte, 2*len(b))
+	for i, c := range b {
+		wide[2*i] = c
+	}
+	return wide
+}
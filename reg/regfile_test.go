@@ -0,0 +1,112 @@
+package reg
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func loadFixture(t *testing.T, name string) *Memory {
+	t.Helper()
+	m, err := LoadRegFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func openKey(t *testing.T, r Registry, path string) Key {
+	t.Helper()
+	root, rest, err := ParseRoot(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k, err := r.OpenKey(root, rest)
+	if err != nil {
+		t.Fatalf("OpenKey(%s): %v", path, err)
+	}
+	return k
+}
+
+func TestParseRegFileVersion5(t *testing.T) {
+	m := loadFixture(t, "version5.reg")
+	k := openKey(t, m, `HKLM\SOFTWARE\DevSpoof\Values`)
+
+	stringValues := []struct{ name, want string }{
+		{"", "default"},
+		{"Quoted", `say "hi" to C:\Windows`},
+		// REG_EXPAND_SZ is returned unexpanded
+		{"Path", `%SystemRoot%\system32`},
+	}
+	for _, tt := range stringValues {
+		if got, err := k.StringValue(tt.name); err != nil || got != tt.want {
+			t.Errorf("StringValue(%q) = %q, %v; want %q", tt.name, got, err, tt.want)
+		}
+	}
+	if got, err := k.StringsValue("List"); err != nil || !slices.Equal(got, []string{"one", "two"}) {
+		t.Errorf(`StringsValue("List") = %q, %v; want ["one" "two"]`, got, err)
+	}
+	if got, err := k.IntegerValue("Count"); err != nil || got != 42 {
+		t.Errorf(`IntegerValue("Count") = %d, %v; want 42`, got, err)
+	}
+	if got, err := k.IntegerValue("Big"); err != nil || got != 0x0123456789ABCDEF {
+		t.Errorf(`IntegerValue("Big") = 0x%X, %v; want 0x123456789ABCDEF`, got, err)
+	}
+	// Wrapped over two lines
+	blob, err := k.BinaryValue("Blob")
+	if err != nil || len(blob) != 26 || blob[0] != 0x00 || blob[25] != 0x19 {
+		t.Errorf(`BinaryValue("Blob") = % X, %v; want 00 through 19`, blob, err)
+	}
+	if _, err := k.StringValue("Removed"); !errors.Is(err, ErrNotExist) {
+		t.Errorf(`deleted value "Removed": err = %v, want ErrNotExist`, err)
+	}
+	if _, err := k.StringValue("Count"); !errors.Is(err, ErrUnexpectedType) {
+		t.Errorf(`StringValue on a DWORD: err = %v, want ErrUnexpectedType`, err)
+	}
+
+	devSpoof := openKey(t, m, `HKLM\SOFTWARE\DevSpoof`)
+	if names, _ := devSpoof.SubKeyNames(); !slices.Equal(names, []string{"Values"}) {
+		t.Errorf("subkeys after [-...Doomed] = %q, want [Values]", names)
+	}
+	// Names are case-insensitive
+	guid := openKey(t, m, `hkey_local_machine\software\microsoft\CRYPTOGRAPHY`)
+	if got, err := guid.StringValue("machineguid"); err != nil || got != "6f1ed2c5-3f6a-4c0b-9d5e-2b7a8c1d4e3f" {
+		t.Errorf("MachineGuid = %q, %v", got, err)
+	}
+}
+
+func TestParseRegFileREGEDIT4(t *testing.T) {
+	k := openKey(t, loadFixture(t, "regedit4.reg"), `HKEY_LOCAL_MACHINE\SOFTWARE\DevSpoof`)
+	if got, err := k.StringValue("Name"); err != nil || got != "REGEDIT4 fixture" {
+		t.Errorf(`StringValue("Name") = %q, %v`, got, err)
+	}
+	if got, err := k.IntegerValue("Flags"); err != nil || got != 0xFFFFFFFF {
+		t.Errorf(`IntegerValue("Flags") = 0x%X, %v`, got, err)
+	}
+	// Single-byte hex(2) and hex(7) data
+	if got, err := k.StringValue("Path"); err != nil || got != `%SystemRoot%\system32` {
+		t.Errorf(`StringValue("Path") = %q, %v`, got, err)
+	}
+	if got, err := k.StringsValue("List"); err != nil || !slices.Equal(got, []string{"one", "two"}) {
+		t.Errorf(`StringsValue("List") = %q, %v`, got, err)
+	}
+}
+
+func TestParseRegFileErrors(t *testing.T) {
+	tests := []struct{ name, data string }{
+		{"no header", "[HKLM\\SOFTWARE]\n"},
+		{"value outside a key", "REGEDIT4\n\"a\"=\"b\"\n"},
+		{"unknown root", "REGEDIT4\n[HKEY_NOWHERE\\SOFTWARE]\n"},
+		{"unterminated key", "REGEDIT4\n[HKLM\\SOFTWARE\n"},
+		{"unterminated string", "REGEDIT4\n[HKLM\\SOFTWARE]\n\"a\"=\"b\n"},
+		{"bad dword", "REGEDIT4\n[HKLM\\SOFTWARE]\n\"a\"=dword:xyz\n"},
+		{"bad hex", "REGEDIT4\n[HKLM\\SOFTWARE]\n\"a\"=hex:0g\n"},
+		{"bad type", "REGEDIT4\n[HKLM\\SOFTWARE]\n\"a\"=hex(zz):00\n"},
+	}
+	for _, tt := range tests {
+		if err := NewMemory(tt.name).ParseRegFile([]byte(tt.data)); err == nil {
+			t.Errorf("%s: parsed without error", tt.name)
+		}
+	}
+}
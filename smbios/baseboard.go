@@ -0,0 +1,99 @@
+package smbios
+
+import (
+	"fmt"
+)
+
+// BaseboardInformation is a decoded Type 2 structure.
+type BaseboardInformation struct {
+	Handle       uint16
+	Manufacturer string
+	Product      string
+	Version      string
+	SerialNumber string
+	AssetTag     string
+	// HasFeatureFlags and HasBoardType are false for structures that end
+	// before the feature flags or before the location, chassis handle and
+	// board type fields.
+	HasFeatureFlags   bool
+	FeatureFlags      byte
+	HasBoardType      bool
+	LocationInChassis string
+	ChassisHandle     uint16
+	BoardType         byte
+	ContainedHandles  []uint16
+}
+
+var baseboardFeatures = []string{
+	"Board is a hosting board",
+	"Board requires at least one daughter board",
+	"Board is removable",
+	"Board is replaceable",
+	"Board is hot swappable",
+}
+
+var boardTypes = []string{
+	"",
+	"Unknown",
+	"Other",
+	"Server Blade",
+	"Connectivity Switch",
+	"System Management Module",
+	"Processor Module",
+	"I/O Module",
+	"Memory Module",
+	"Daughter Board",
+	"Motherboard",
+	"Processor+Memory Module",
+	"Processor+I/O Module",
+	"Interconnect Board",
+}
+
+// DecodeBaseboardInformation decodes a Type 2 structure.
+func DecodeBaseboardInformation(s *Structure) (BaseboardInformation, error) {
+	if s.Type != TypeBaseboard {
+		return BaseboardInformation{}, fmt.Errorf("structure 0x%04X is type %d, not baseboard information", s.Handle, s.Type)
+	}
+	if s.Length < 0x08 {
+		return BaseboardInformation{}, fmt.Errorf("baseboard structure (Type 2) too short (%d bytes)", s.Length)
+	}
+
+	b := BaseboardInformation{
+		Handle:            s.Handle,
+		Manufacturer:      s.stringField(0x04),
+		Product:           s.stringField(0x05),
+		Version:           s.stringField(0x06),
+		SerialNumber:      s.stringField(0x07),
+		AssetTag:          s.stringField(0x08),
+		LocationInChassis: s.stringField(0x0A),
+	}
+	b.FeatureFlags, b.HasFeatureFlags = s.Byte(0x09)
+	b.ChassisHandle, _ = s.Word(0x0B)
+	b.BoardType, b.HasBoardType = s.Byte(0x0D)
+
+	count, _ := s.Byte(0x0E)
+	for i := 0; i < int(count); i++ {
+		handle, ok := s.Word(0x0F + 2*i)
+		if !ok {
+			break
+		}
+		b.ContainedHandles = append(b.ContainedHandles, handle)
+	}
+	return b, nil
+}
+
+// FeatureNames lists the feature flags that are set.
+func (b BaseboardInformation) FeatureNames() []string {
+	var names []string
+	for bit, name := range baseboardFeatures {
+		if b.FeatureFlags&(1<<bit) != 0 {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// BoardTypeString names the board type, e.g. "Motherboard".
+func (b BaseboardInformation) BoardTypeString() string {
+	return enumString(boardTypes, b.BoardType)
+}
@@ -0,0 +1,62 @@
+package smbios
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBaseboardInformationFixture(t *testing.T) {
+	b, err := DecodeBaseboardInformation(parseFixture(t, "rsmb_3_2.bin").First(TypeBaseboard))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Product != "0J37VM" || b.SerialNumber != "/8XQ4LN3/CNFCW0009P00BH/" {
+		t.Errorf("product = %q, serial = %q; want 0J37VM, /8XQ4LN3/CNFCW0009P00BH/", b.Product, b.SerialNumber)
+	}
+	if !b.HasBoardType || b.BoardTypeString() != "Motherboard" || b.ChassisHandle != 0x0003 {
+		t.Errorf("board type = %v %q, chassis handle = 0x%04X; want Motherboard, 0x0003", b.HasBoardType, b.BoardTypeString(), b.ChassisHandle)
+	}
+	want := []string{"Board is a hosting board", "Board is replaceable"}
+	if !b.HasFeatureFlags || !reflect.DeepEqual(b.FeatureNames(), want) {
+		t.Errorf("features = %v %q, want %q", b.HasFeatureFlags, b.FeatureNames(), want)
+	}
+}
+
+func TestBaseboardContainedHandles(t *testing.T) {
+	fields := []byte{1, 2, 3, 4, 5, 0x01, 0, 0x03, 0x00, 0x0A, 2, 0x10, 0x00, 0x11, 0x00}
+	b, err := DecodeBaseboardInformation(structure(TypeBaseboard, fields...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []uint16{0x0010, 0x0011}; !reflect.DeepEqual(b.ContainedHandles, want) {
+		t.Errorf("contained handles = %04X, want %04X", b.ContainedHandles, want)
+	}
+
+	// A count larger than the structure stops at its end
+	b, err = DecodeBaseboardInformation(structure(TypeBaseboard, append(fields[:10:10], 3, 0x10, 0x00)...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []uint16{0x0010}; !reflect.DeepEqual(b.ContainedHandles, want) {
+		t.Errorf("overlong count: contained handles = %04X, want %04X", b.ContainedHandles, want)
+	}
+}
+
+func TestBaseboardInformationShort(t *testing.T) {
+	// The smallest structure holds only the five strings
+	b, err := DecodeBaseboardInformation(structure(TypeBaseboard, 1, 2, 3, 4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.HasFeatureFlags || b.HasBoardType {
+		t.Errorf("short structure decoded fields beyond its length: %+v", b)
+	}
+
+	b, err = DecodeBaseboardInformation(structure(TypeBaseboard, 1, 2, 3, 4, 5, 0x09))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !b.HasFeatureFlags || b.HasBoardType {
+		t.Errorf("structure ending after the feature flags: HasFeatureFlags = %v, HasBoardType = %v", b.HasFeatureFlags, b.HasBoardType)
+	}
+}
@@ -0,0 +1,188 @@
+package smbios
+
+import (
+	"fmt"
+)
+
+// BIOSInformation is a decoded Type 0 structure.
+type BIOSInformation struct {
+	Handle          uint16
+	Vendor          string
+	Version         string
+	StartingSegment uint16
+	ReleaseDate     string
+	// ROMSize is in bytes; it comes from the Extended BIOS ROM Size field when
+	// the legacy byte is 0xFF.
+	ROMSize uint64
+	// ROMSizeReserved is set when the Extended BIOS ROM Size field uses one of
+	// the reserved units (10b, 11b); ROMSize is then zero.
+	ROMSizeReserved    bool
+	Characteristics    uint64
+	CharacteristicsExt []byte
+	// HasReleases is false for pre-2.4 structures without the release fields.
+	HasReleases bool
+	// Release fields are 0xFF when the BIOS does not report them.
+	SystemBIOSMajor byte
+	SystemBIOSMinor byte
+	ECFirmwareMajor byte
+	ECFirmwareMinor byte
+}
+
+// biosCharacteristics names bits 3-31 of the Characteristics qword.
+var biosCharacteristics = map[int]string{
+	3:  "BIOS characteristics not supported",
+	4:  "ISA is supported",
+	5:  "MCA is supported",
+	6:  "EISA is supported",
+	7:  "PCI is supported",
+	8:  "PC Card (PCMCIA) is supported",
+	9:  "PNP is supported",
+	10: "APM is supported",
+	11: "BIOS is upgradeable",
+	12: "BIOS shadowing is allowed",
+	13: "VLB is supported",
+	14: "ESCD support is available",
+	15: "Boot from CD is supported",
+	16: "Selectable boot is supported",
+	17: "BIOS ROM is socketed",
+	18: "Boot from PC Card (PCMCIA) is supported",
+	19: "EDD is supported",
+	20: "Japanese floppy for NEC 9800 1.2 MB is supported (int 13h)",
+	21: "Japanese floppy for Toshiba 1.2 MB is supported (int 13h)",
+	22: "5.25\"/360 kB floppy services are supported (int 13h)",
+	23: "5.25\"/1.2 MB floppy services are supported (int 13h)",
+	24: "3.5\"/720 kB floppy services are supported (int 13h)",
+	25: "3.5\"/2.88 MB floppy services are supported (int 13h)",
+	26: "Print screen service is supported (int 5h)",
+	27: "8042 keyboard services are supported (int 9h)",
+	28: "Serial services are supported (int 14h)",
+	29: "Printer services are supported (int 17h)",
+	30: "CGA/mono video services are supported (int 10h)",
+	31: "NEC PC-98",
+}
+
+// biosCharacteristicsExt names the bits of the two extension bytes.
+var biosCharacteristicsExt = [2][]string{
+	{
+		"ACPI is supported",
+		"USB legacy is supported",
+		"AGP is supported",
+		"I2O boot is supported",
+		"LS-120 boot is supported",
+		"ATAPI Zip drive boot is supported",
+		"IEEE 1394 boot is supported",
+		"Smart battery is supported",
+	},
+	{
+		"BIOS boot specification is supported",
+		"Function key-initiated network boot is supported",
+		"Targeted content distribution is supported",
+		"UEFI is supported",
+		"System is a virtual machine",
+		"Manufacturing mode is supported",
+		"Manufacturing mode is enabled",
+	},
+}
+
+// DecodeBIOSInformation decodes a Type 0 structure. Fields introduced in
+// later SMBIOS versions are left zero when the structure is too short.
+func DecodeBIOSInformation(s *Structure) (BIOSInformation, error) {
+	if s.Type != TypeBIOSInformation {
+		return BIOSInformation{}, fmt.Errorf("structure 0x%04X is type %d, not BIOS information", s.Handle, s.Type)
+	}
+	if s.Length < 0x12 {
+		return BIOSInformation{}, fmt.Errorf("BIOS information structure (Type 0) too short (%d bytes)", s.Length)
+	}
+
+	b := BIOSInformation{
+		Handle:          s.Handle,
+		Vendor:          s.stringField(0x04),
+		Version:         s.stringField(0x05),
+		ReleaseDate:     s.stringField(0x08),
+		SystemBIOSMajor: 0xFF,
+		SystemBIOSMinor: 0xFF,
+		ECFirmwareMajor: 0xFF,
+		ECFirmwareMinor: 0xFF,
+	}
+	b.StartingSegment, _ = s.Word(0x06)
+	b.Characteristics, _ = s.QWord(0x0A)
+
+	romSize, _ := s.Byte(0x09)
+	b.ROMSize = 64 * 1024 * (uint64(romSize) + 1)
+	if ext, ok := s.Word(0x18); ok && romSize == 0xFF {
+		// Bits 15:14 select the unit (00 = MB, 01 = GB), bits 13:0 the size
+		size := uint64(ext & 0x3FFF)
+		switch ext >> 14 {
+		case 0:
+			b.ROMSize = size << 20
+		case 1:
+			b.ROMSize = size << 30
+		default:
+			b.ROMSize, b.ROMSizeReserved = 0, true
+		}
+	}
+
+	if s.Length > 0x12 {
+		end := min(int(s.Length), 0x14)
+		b.CharacteristicsExt = s.Formatted[0x12:end]
+	}
+	if releases, ok := s.Bytes(0x14, 4); ok {
+		b.SystemBIOSMajor, b.SystemBIOSMinor = releases[0], releases[1]
+		b.ECFirmwareMajor, b.ECFirmwareMinor = releases[2], releases[3]
+		b.HasReleases = true
+	}
+	return b, nil
+}
+
+// CharacteristicNames lists the characteristics flagged in the Characteristics
+// qword and the extension bytes.
+func (b BIOSInformation) CharacteristicNames() []string {
+	var names []string
+	// Bit 3 means the other qword bits carry no information; the extension bytes still do
+	if b.Characteristics&(1<<3) != 0 {
+		names = append(names, biosCharacteristics[3])
+	} else {
+		for bit := 4; bit <= 31; bit++ {
+			if b.Characteristics&(1<<bit) != 0 {
+				names = append(names, biosCharacteristics[bit])
+			}
+		}
+	}
+	for i, ext := range b.CharacteristicsExt {
+		for bit, name := range biosCharacteristicsExt[i] {
+			if ext&(1<<bit) != 0 {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// ROMSizeString formats ROMSize the way dmidecode does.
+func (b BIOSInformation) ROMSizeString() string {
+	switch {
+	case b.ROMSizeReserved:
+		return "Reserved"
+	case b.ROMSize >= 1<<30 && b.ROMSize%(1<<30) == 0:
+		return fmt.Sprintf("%d GB", b.ROMSize>>30)
+	case b.ROMSize >= 1<<20 && b.ROMSize%(1<<20) == 0:
+		return fmt.Sprintf("%d MB", b.ROMSize>>20)
+	}
+	return fmt.Sprintf("%d kB", b.ROMSize>>10)
+}
+
+// BIOSRevision returns the system BIOS release as "major.minor", or "" when not reported.
+func (b BIOSInformation) BIOSRevision() string {
+	if b.SystemBIOSMajor == 0xFF || b.SystemBIOSMinor == 0xFF {
+		return ""
+	}
+	return fmt.Sprintf("%d.%d", b.SystemBIOSMajor, b.SystemBIOSMinor)
+}
+
+// FirmwareRevision returns the embedded controller firmware release as "major.minor", or "" when not reported.
+func (b BIOSInformation) FirmwareRevision() string {
+	if b.ECFirmwareMajor == 0xFF || b.ECFirmwareMinor == 0xFF {
+		return ""
+	}
+	return fmt.Sprintf("%d.%d", b.ECFirmwareMajor, b.ECFirmwareMinor)
+}
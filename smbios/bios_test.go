@@ -0,0 +1,68 @@
+package smbios
+
+import "testing"
+
+// structure builds a structure from its formatted area after the 4-byte header.
+func structure(typ uint8, fields ...byte) *Structure {
+	formatted := append([]byte{typ, byte(HeaderSize + len(fields)), 0, 0}, fields...)
+	return &Structure{Type: typ, Length: uint8(len(formatted)), Formatted: formatted}
+}
+
+func TestBIOSInformationFixtures(t *testing.T) {
+	tests := []struct {
+		fixture     string
+		romSize     string
+		hasReleases bool
+		revision    string
+	}{
+		{"rsmb_3_2.bin", "32 MB", true, "1.13"},
+		{"rsmb_2_4.bin", "1 MB", true, ""},
+	}
+	for _, tt := range tests {
+		bios, err := DecodeBIOSInformation(parseFixture(t, tt.fixture).First(TypeBIOSInformation))
+		if err != nil {
+			t.Fatalf("%s: %v", tt.fixture, err)
+		}
+		if got := bios.ROMSizeString(); got != tt.romSize {
+			t.Errorf("%s: ROM size = %q, want %q", tt.fixture, got, tt.romSize)
+		}
+		if bios.HasReleases != tt.hasReleases || bios.BIOSRevision() != tt.revision {
+			t.Errorf("%s: releases = %v %q, want %v %q", tt.fixture, bios.HasReleases, bios.BIOSRevision(), tt.hasReleases, tt.revision)
+		}
+	}
+}
+
+func TestBIOSExtendedROMSize(t *testing.T) {
+	tests := []struct {
+		ext  uint16
+		want string
+	}{
+		{0x0010, "16 MB"},
+		{0x4002, "2 GB"},
+		{0x8010, "Reserved"},
+		{0xC010, "Reserved"},
+	}
+	for _, tt := range tests {
+		fields := make([]byte, 0x1A-HeaderSize)
+		fields[0x09-HeaderSize] = 0xFF
+		fields[0x18-HeaderSize], fields[0x19-HeaderSize] = byte(tt.ext), byte(tt.ext>>8)
+		bios, err := DecodeBIOSInformation(structure(TypeBIOSInformation, fields...))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := bios.ROMSizeString(); got != tt.want {
+			t.Errorf("extended ROM size 0x%04X = %q, want %q", tt.ext, got, tt.want)
+		}
+	}
+}
+
+func TestBIOSInformationShort(t *testing.T) {
+	// A 2.0 structure ends after the Characteristics qword
+	bios, err := DecodeBIOSInformation(structure(TypeBIOSInformation, make([]byte, 0x12-HeaderSize)...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bios.HasReleases || len(bios.CharacteristicsExt) != 0 {
+		t.Errorf("2.0 structure decoded fields beyond its length: %+v", bios)
+	}
+}
@@ -0,0 +1,156 @@
+package smbios
+
+import (
+	"fmt"
+)
+
+// ChassisInformation is a decoded Type 3 structure.
+type ChassisInformation struct {
+	Handle       uint16
+	Manufacturer string
+	// Type is the enclosure type with the lock bit (bit 7) masked off.
+	Type         byte
+	Locked       bool
+	Version      string
+	SerialNumber string
+	AssetTag     string
+	// HasStates is false for 2.0 structures, which end before the boot-up,
+	// power supply, thermal and security fields.
+	HasStates        bool
+	BootUpState      byte
+	PowerSupplyState byte
+	ThermalState     byte
+	SecurityStatus   byte
+	OEMInformation   uint32
+	Height           byte
+	PowerCords       byte
+	SKUNumber        string
+}
+
+var chassisTypes = []string{
+	"",
+	"Other",
+	"Unknown",
+	"Desktop",
+	"Low Profile Desktop",
+	"Pizza Box",
+	"Mini Tower",
+	"Tower",
+	"Portable",
+	"Laptop",
+	"Notebook",
+	"Hand Held",
+	"Docking Station",
+	"All In One",
+	"Sub Notebook",
+	"Space-saving",
+	"Lunch Box",
+	"Main Server Chassis",
+	"Expansion Chassis",
+	"Sub Chassis",
+	"Bus Expansion Chassis",
+	"Peripheral Chassis",
+	"RAID Chassis",
+	"Rack Mount Chassis",
+	"Sealed-case PC",
+	"Multi-system",
+	"CompactPCI",
+	"AdvancedTCA",
+	"Blade",
+	"Blade Enclosing",
+	"Tablet",
+	"Convertible",
+	"Detachable",
+	"IoT Gateway",
+	"Embedded PC",
+	"Mini PC",
+	"Stick PC",
+}
+
+var chassisStates = []string{
+	"",
+	"Other",
+	"Unknown",
+	"Safe",
+	"Warning",
+	"Critical",
+	"Non-recoverable",
+}
+
+var chassisSecurityStatuses = []string{
+	"",
+	"Other",
+	"Unknown",
+	"None",
+	"External Interface Locked Out",
+	"External Interface Enabled",
+}
+
+// DecodeChassisInformation decodes a Type 3 structure.
+func DecodeChassisInformation(s *Structure) (ChassisInformation, error) {
+	if s.Type != TypeChassis {
+		return ChassisInformation{}, fmt.Errorf("structure 0x%04X is type %d, not chassis information", s.Handle, s.Type)
+	}
+	if s.Length < 0x09 {
+		return ChassisInformation{}, fmt.Errorf("chassis structure (Type 3) too short (%d bytes)", s.Length)
+	}
+
+	c := ChassisInformation{
+		Handle:       s.Handle,
+		Manufacturer: s.stringField(0x04),
+		Version:      s.stringField(0x06),
+		SerialNumber: s.stringField(0x07),
+		AssetTag:     s.stringField(0x08),
+	}
+	typ, _ := s.Byte(0x05)
+	c.Type = typ & 0x7F
+	c.Locked = typ&0x80 != 0
+	if states, ok := s.Bytes(0x09, 4); ok {
+		c.BootUpState, c.PowerSupplyState, c.ThermalState, c.SecurityStatus = states[0], states[1], states[2], states[3]
+		c.HasStates = true
+	}
+	c.OEMInformation, _ = s.DWord(0x0D)
+	c.Height, _ = s.Byte(0x11)
+	c.PowerCords, _ = s.Byte(0x12)
+
+	// The SKU string follows the variable-length contained element records
+	count, ok := s.Byte(0x13)
+	recordLength, ok2 := s.Byte(0x14)
+	if ok && ok2 {
+		c.SKUNumber = s.stringField(0x15 + int(count)*int(recordLength))
+	}
+	return c, nil
+}
+
+// TypeString names the enclosure type, e.g. "Desktop".
+func (c ChassisInformation) TypeString() string {
+	return enumString(chassisTypes, c.Type)
+}
+
+// BootUpStateString names the boot-up state, e.g. "Safe".
+func (c ChassisInformation) BootUpStateString() string {
+	return enumString(chassisStates, c.BootUpState)
+}
+
+// PowerSupplyStateString names the power supply state.
+func (c ChassisInformation) PowerSupplyStateString() string {
+	return enumString(chassisStates, c.PowerSupplyState)
+}
+
+// ThermalStateString names the thermal state.
+func (c ChassisInformation) ThermalStateString() string {
+	return enumString(chassisStates, c.ThermalState)
+}
+
+// SecurityStatusString names the security status.
+func (c ChassisInformation) SecurityStatusString() string {
+	return enumString(chassisSecurityStatuses, c.SecurityStatus)
+}
+
+// enumString looks v up in a 1-based name table.
+func enumString(names []string, v byte) string {
+	if v > 0 && int(v) < len(names) {
+		return names[v]
+	}
+	return fmt.Sprintf("Unknown (0x%02X)", v)
+}
@@ -0,0 +1,47 @@
+package smbios
+
+import "testing"
+
+func TestChassisInformationFixture(t *testing.T) {
+	c, err := DecodeChassisInformation(parseFixture(t, "rsmb_3_2.bin").First(TypeChassis))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.TypeString() != "Desktop" || c.Locked || c.SerialNumber != "8XQ4LN3" {
+		t.Errorf("type = %q, locked = %v, serial = %q; want Desktop, false, 8XQ4LN3", c.TypeString(), c.Locked, c.SerialNumber)
+	}
+	if !c.HasStates || c.BootUpStateString() != "Safe" || c.ThermalStateString() != "Safe" || c.SecurityStatusString() != "None" {
+		t.Errorf("states = %v %q/%q/%q, want Safe/Safe/None", c.HasStates, c.BootUpStateString(), c.ThermalStateString(), c.SecurityStatusString())
+	}
+	if c.PowerCords != 1 || c.SKUNumber != "Desktop" {
+		t.Errorf("power cords = %d, SKU = %q; want 1, Desktop", c.PowerCords, c.SKUNumber)
+	}
+}
+
+func TestChassisSKUAfterContainedElements(t *testing.T) {
+	// Two 3-byte contained element records push the SKU string to 0x1B
+	fields := make([]byte, 0x1C-HeaderSize)
+	fields[0x05-HeaderSize] = 0x83
+	fields[0x13-HeaderSize], fields[0x14-HeaderSize] = 2, 3
+	fields[0x1B-HeaderSize] = 1
+	s := structure(TypeChassis, fields...)
+	s.Strings = []string{"SKU-1"}
+	c, err := DecodeChassisInformation(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.TypeString() != "Desktop" || !c.Locked || c.SKUNumber != "SKU-1" {
+		t.Errorf("type = %q, locked = %v, SKU = %q; want Desktop, true, SKU-1", c.TypeString(), c.Locked, c.SKUNumber)
+	}
+}
+
+func TestChassisInformationShort(t *testing.T) {
+	// A 2.0 structure ends after the asset tag
+	c, err := DecodeChassisInformation(structure(TypeChassis, 1, 0x03, 0, 0, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.HasStates || c.SKUNumber != "" {
+		t.Errorf("2.0 structure decoded fields beyond its length: %+v", c)
+	}
+}
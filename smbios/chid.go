@@ -0,0 +1,139 @@
+package smbios
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+)
+
+// chidNamespace is the UUIDv5 namespace Microsoft uses for Computer Hardware IDs.
+var chidNamespace = UUID{0x70, 0xFF, 0xD8, 0x12, 0x4C, 0x7F, 0x4C, 0x7D, 0, 0, 0, 0, 0, 0, 0, 0}
+
+// CHID field names, as ComputerHardwareIds.exe labels them.
+const (
+	CHIDManufacturer          = "Manufacturer"
+	CHIDFamily                = "Family"
+	CHIDProductName           = "ProductName"
+	CHIDProductSku            = "ProductSku"
+	CHIDEnclosureKind         = "EnclosureKind"
+	CHIDBaseboardManufacturer = "BaseboardManufacturer"
+	CHIDBaseboardProduct      = "BaseboardProduct"
+	CHIDBiosVendor            = "BiosVendor"
+	CHIDBiosVersion           = "BiosVersion"
+	CHIDBiosMajorRelease      = "BiosMajorRelease"
+	CHIDBiosMinorRelease      = "BiosMinorRelease"
+)
+
+// chidVariants lists the fields hashed into HardwareID-0 through HardwareID-14.
+var chidVariants = [][]string{
+	{CHIDManufacturer, CHIDFamily, CHIDProductName, CHIDProductSku, CHIDBiosVendor, CHIDBiosVersion, CHIDBiosMajorRelease, CHIDBiosMinorRelease},
+	{CHIDManufacturer, CHIDFamily, CHIDProductName, CHIDBiosVendor, CHIDBiosVersion, CHIDBiosMajorRelease, CHIDBiosMinorRelease},
+	{CHIDManufacturer, CHIDProductName, CHIDBiosVendor, CHIDBiosVersion, CHIDBiosMajorRelease, CHIDBiosMinorRelease},
+	{CHIDManufacturer, CHIDFamily, CHIDProductName, CHIDProductSku, CHIDBaseboardManufacturer, CHIDBaseboardProduct},
+	{CHIDManufacturer, CHIDFamily, CHIDProductName, CHIDProductSku},
+	{CHIDManufacturer, CHIDFamily, CHIDProductName},
+	{CHIDManufacturer, CHIDProductSku, CHIDBaseboardManufacturer, CHIDBaseboardProduct},
+	{CHIDManufacturer, CHIDProductSku},
+	{CHIDManufacturer, CHIDProductName, CHIDBaseboardManufacturer, CHIDBaseboardProduct},
+	{CHIDManufacturer, CHIDProductName},
+	{CHIDManufacturer, CHIDFamily, CHIDBaseboardManufacturer, CHIDBaseboardProduct},
+	{CHIDManufacturer, CHIDFamily},
+	{CHIDManufacturer, CHIDEnclosureKind},
+	{CHIDManufacturer, CHIDBaseboardManufacturer, CHIDBaseboardProduct},
+	{CHIDManufacturer},
+}
+
+// CHID is one computed Computer Hardware ID.
+type CHID struct {
+	// Name is "HardwareID-<n>".
+	Name   string
+	Fields []string
+	// Input is the "&"-joined string that was hashed.
+	Input string
+	ID    UUID
+	// Missing lists fields the table does not provide; ID is not computed
+	// when any are missing.
+	Missing []string
+}
+
+// CHIDFields collects the values hashed into CHIDs from the table, keyed by
+// field name. Strings are trimmed; releases are two-digit hex and the
+// enclosure kind is plain hex, matching ComputerHardwareIds.exe.
+func CHIDFields(t *Table) map[string]string {
+	fields := make(map[string]string)
+	set := func(key, value string) {
+		if value = strings.TrimSpace(value); value != "" && value != BadIndex {
+			fields[key] = value
+		}
+	}
+
+	if s := t.First(TypeSystemInformation); s != nil {
+		set(CHIDManufacturer, s.stringField(0x04))
+		set(CHIDProductName, s.stringField(0x05))
+		set(CHIDProductSku, s.stringField(0x19))
+		set(CHIDFamily, s.stringField(0x1A))
+	}
+	if s := t.First(TypeBIOSInformation); s != nil {
+		set(CHIDBiosVendor, s.stringField(0x04))
+		set(CHIDBiosVersion, s.stringField(0x05))
+		if major, ok := s.Byte(0x14); ok {
+			set(CHIDBiosMajorRelease, fmt.Sprintf("%02x", major))
+		}
+		if minor, ok := s.Byte(0x15); ok {
+			set(CHIDBiosMinorRelease, fmt.Sprintf("%02x", minor))
+		}
+	}
+	if s := t.First(TypeBaseboard); s != nil {
+		set(CHIDBaseboardManufacturer, s.stringField(0x04))
+		set(CHIDBaseboardProduct, s.stringField(0x05))
+	}
+	if s := t.First(TypeChassis); s != nil {
+		if kind, ok := s.Byte(0x05); ok {
+			set(CHIDEnclosureKind, fmt.Sprintf("%x", kind&0x7F))
+		}
+	}
+	return fields
+}
+
+// ComputeCHIDs computes HardwareID-0 through HardwareID-14 from the table.
+func ComputeCHIDs(t *Table) []CHID {
+	fields := CHIDFields(t)
+	chids := make([]CHID, 0, len(chidVariants))
+	for i, keys := range chidVariants {
+		chid := CHID{Name: fmt.Sprintf("HardwareID-%d", i), Fields: keys}
+		values := make([]string, 0, len(keys))
+		for _, key := range keys {
+			value, ok := fields[key]
+			if !ok {
+				chid.Missing = append(chid.Missing, key)
+			}
+			values = append(values, value)
+		}
+		chid.Input = strings.Join(values, "&")
+		if len(chid.Missing) == 0 {
+			chid.ID = CHIDFromString(chid.Input)
+		}
+		chids = append(chids, chid)
+	}
+	return chids
+}
+
+// CHIDFromString hashes a CHID input string: a UUIDv5 (SHA-1) over its
+// UTF-16LE encoding in Microsoft's CHID namespace.
+func CHIDFromString(input string) UUID {
+	encoded := utf16.Encode([]rune(input))
+	data := make([]byte, 0, len(encoded)*2)
+	for _, c := range encoded {
+		data = append(data, byte(c), byte(c>>8))
+	}
+
+	h := sha1.New()
+	h.Write(chidNamespace[:])
+	h.Write(data)
+	var id UUID
+	copy(id[:], h.Sum(nil))
+	id[6] = id[6]&0x0F | 0x50
+	id[8] = id[8]&0x3F | 0x80
+	return id
+}
@@ -0,0 +1,61 @@
+package smbios
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCHIDFromString(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		// fwupd's HardwareID-14 for Lenovo machines
+		{"LENOVO", "6DE5D951-D755-576B-BD09-C5CF66B27234"},
+		{"Dell Inc.", "85D38FDA-FC0E-5C6F-808F-076984AE7978"},
+		{"Dell Inc.&OptiPlex 7080", "27544A3C-C769-5275-8826-D9B57BC41D3A"},
+	}
+	for _, tt := range tests {
+		if got := CHIDFromString(tt.input).String(); got != tt.want {
+			t.Errorf("CHIDFromString(%q) = %s, want %s", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestComputeCHIDs(t *testing.T) {
+	tests := []struct {
+		fixture string
+		index   int
+		input   string
+		id      string
+		missing []string
+	}{
+		{"rsmb_3_2.bin", 0, "Dell Inc.&OptiPlex&OptiPlex 7080&09E3&Dell Inc.&1.13.0&01&0d", "3E31F822-C7FF-5312-92D7-9D3979A16302", nil},
+		{"rsmb_3_2.bin", 12, "Dell Inc.&3", "529AEA4B-4247-5705-89E2-EDC2EDD08C4E", nil},
+		{"rsmb_3_2.bin", 13, "Dell Inc.&Dell Inc.&0J37VM", "B0521213-46A3-5440-8694-86BF86CE459E", nil},
+		{"rsmb_3_2.bin", 14, "Dell Inc.", "85D38FDA-FC0E-5C6F-808F-076984AE7978", nil},
+		// The 2.4 table has no SKU, family, baseboard or chassis, so only the
+		// variants without them get an ID
+		{"rsmb_2_4.bin", 0, "Dell Inc.&&OptiPlex 745&&Phoenix Technologies LTD&6.00&ff&ff", "00000000-0000-0000-0000-000000000000", []string{CHIDFamily, CHIDProductSku}},
+		{"rsmb_2_4.bin", 2, "Dell Inc.&OptiPlex 745&Phoenix Technologies LTD&6.00&ff&ff", "57E0FD9F-A1BB-5A2F-BB0C-F9960DA39E0D", nil},
+		{"rsmb_2_4.bin", 3, "Dell Inc.&&OptiPlex 745&&&", "00000000-0000-0000-0000-000000000000", []string{CHIDFamily, CHIDProductSku, CHIDBaseboardManufacturer, CHIDBaseboardProduct}},
+		{"rsmb_2_4.bin", 9, "Dell Inc.&OptiPlex 745", "E9FE5038-5395-5D34-93CD-8B8DB8A68E8D", nil},
+		{"rsmb_2_4.bin", 12, "Dell Inc.&", "00000000-0000-0000-0000-000000000000", []string{CHIDEnclosureKind}},
+	}
+	chids := map[string][]CHID{}
+	for _, tt := range tests {
+		if chids[tt.fixture] == nil {
+			chids[tt.fixture] = ComputeCHIDs(parseFixture(t, tt.fixture))
+			if got := len(chids[tt.fixture]); got != 15 {
+				t.Fatalf("%s: got %d CHIDs, want 15", tt.fixture, got)
+			}
+		}
+		chid := chids[tt.fixture][tt.index]
+		if chid.Input != tt.input || chid.ID.String() != tt.id {
+			t.Errorf("%s %s = %q %s, want %q %s", tt.fixture, chid.Name, chid.Input, chid.ID, tt.input, tt.id)
+		}
+		if !reflect.DeepEqual(chid.Missing, tt.missing) {
+			t.Errorf("%s %s missing %q, want %q", tt.fixture, chid.Name, chid.Missing, tt.missing)
+		}
+	}
+}
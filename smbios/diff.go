@@ -0,0 +1,46 @@
+package smbios
+
+// ByteRange is a run of bytes that differ between two buffers.
+type ByteRange struct {
+	Offset int
+	Length int
+}
+
+// DiffRaw returns the runs of bytes that differ between two RawSMBIOSData
+// buffers. When the lengths differ, the extra tail is reported as one run.
+func DiffRaw(before, after []byte) []ByteRange {
+	var ranges []ByteRange
+	common := min(len(before), len(after))
+	for i := 0; i < common; i++ {
+		if before[i] == after[i] {
+			continue
+		}
+		start := i
+		for i < common && before[i] != after[i] {
+			i++
+		}
+		ranges = append(ranges, ByteRange{Offset: start, Length: i - start})
+	}
+	if len(before) != len(after) {
+		ranges = append(ranges, ByteRange{Offset: common, Length: max(len(before), len(after)) - common})
+	}
+	return ranges
+}
+
+// StructureAt returns the structure (formatted area or string set) that
+// contains the given RawSMBIOSData buffer offset, or nil for the header and
+// anything past the last structure.
+func (t *Table) StructureAt(offset int) *Structure {
+	offset -= RawHeaderSize
+	if offset < 0 || offset >= len(t.Data) {
+		return nil
+	}
+	var found *Structure
+	for i := range t.Structures {
+		if t.Structures[i].Offset > offset {
+			break
+		}
+		found = &t.Structures[i]
+	}
+	return found
+}
@@ -0,0 +1,88 @@
+package smbios
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffRaw(t *testing.T) {
+	before := readFixture(t, "rsmb_3_2.bin")
+	// rsmb_3_2_spoofed.bin has the Type 1 serial number rewritten and the
+	// Type 2 feature flags changed from 0x09 to 0x0B
+	after := readFixture(t, "rsmb_3_2_spoofed.bin")
+	table := parseFixture(t, "rsmb_3_2_spoofed.bin")
+
+	changes := DiffRaw(before, after)
+	want := []ByteRange{{Offset: 0x72, Length: 7}, {Offset: 0x92, Length: 1}}
+	if !reflect.DeepEqual(changes, want) {
+		t.Fatalf("DiffRaw = %+v, want %+v", changes, want)
+	}
+
+	located := []struct {
+		typ    uint8
+		handle uint16
+		// field is the change's offset within the structure
+		field int
+	}{
+		{TypeSystemInformation, 0x0001, 0x33},
+		{TypeBaseboard, 0x0002, 0x09},
+	}
+	for i, change := range changes {
+		s := table.StructureAt(change.Offset)
+		if s == nil {
+			t.Errorf("change at 0x%04X: no structure", change.Offset)
+			continue
+		}
+		want := located[i]
+		if s.Type != want.typ || s.Handle != want.handle || change.Offset-RawHeaderSize-s.Offset != want.field {
+			t.Errorf("change at 0x%04X is in type %d, handle 0x%04X at +0x%02X; want type %d, handle 0x%04X at +0x%02X",
+				change.Offset, s.Type, s.Handle, change.Offset-RawHeaderSize-s.Offset, want.typ, want.handle, want.field)
+		}
+	}
+	if serial, err := table.First(TypeSystemInformation).GetString(0x07); err != nil || serial != "5CD1234" {
+		t.Errorf("spoofed serial = %q, %v; want 5CD1234", serial, err)
+	}
+}
+
+func TestDiffRawLength(t *testing.T) {
+	before := []byte{1, 2, 3, 4}
+	tests := []struct {
+		after []byte
+		want  []ByteRange
+	}{
+		{[]byte{1, 2, 3, 4}, nil},
+		{[]byte{1, 2, 3, 4, 5, 6}, []ByteRange{{4, 2}}},
+		{[]byte{9, 2}, []ByteRange{{0, 1}, {2, 2}}},
+		{[]byte{9, 9, 3, 9}, []ByteRange{{0, 2}, {3, 1}}},
+	}
+	for _, tt := range tests {
+		if got := DiffRaw(before, tt.after); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("DiffRaw(% X, % X) = %v, want %v", before, tt.after, got, tt.want)
+		}
+	}
+}
+
+func TestStructureAt(t *testing.T) {
+	table := parseFixture(t, "rsmb_3_2.bin")
+	tests := []struct {
+		offset int
+		handle int // -1 for no structure
+	}{
+		{0x00, -1},
+		{RawHeaderSize - 1, -1},
+		{RawHeaderSize, 0x0000},
+		// The last byte of Type 0's string set
+		{0x3E, 0x0000},
+		{0x3F, 0x0001},
+		{RawHeaderSize + int(table.Length), -1},
+	}
+	for _, tt := range tests {
+		s := table.StructureAt(tt.offset)
+		switch {
+		case s == nil && tt.handle >= 0:
+			t.Errorf("StructureAt(0x%04X) = nil, want handle 0x%04X", tt.offset, tt.handle)
+		case s != nil && int(s.Handle) != tt.handle:
+			t.Errorf("StructureAt(0x%04X) = handle 0x%04X, want %d", tt.offset, s.Handle, tt.handle)
+		}
+	}
+}
@@ -0,0 +1,1326 @@
+package smbios
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// outOfSpec is what dmidecode prints for values the specification does not define.
+const outOfSpec = "<OUT OF SPEC>"
+
+// DmidecodeSourceLine is the line dmidecode prints about where it read the table, for
+// the source it has an equivalent of, or "" for the others such as the Windows RSMB table.
+func DmidecodeSourceLine(src Source) string {
+	switch src := src.(type) {
+	case FileSource:
+		return "Reading SMBIOS/DMI data from file " + src.Path + "."
+	case SysfsSource:
+		return "Getting SMBIOS data from sysfs."
+	}
+	return ""
+}
+
+// RenderDmidecode renders the table the way dmidecode 3.5 prints it, so that it can
+// be diffed against a Linux capture of the same machine. sourceLine, usually from
+// DmidecodeSourceLine, is left out when empty. The "Table at" line is left out
+// because RawSMBIOSData does not carry the table address. Types 0-4, 7-14, 16-20,
+// 24, 32, 41, 43, 126 and 127 are decoded; every other structure is dumped the way
+// dmidecode dumps types it has no decoder for (header, data and strings).
+func RenderDmidecode(t *Table, sourceLine string) string {
+	var b strings.Builder
+	b.WriteString("# dmidecode 3.5\n")
+	if sourceLine != "" {
+		b.WriteString(sourceLine + "\n")
+	}
+	if t.MajorVersion >= 3 {
+		fmt.Fprintf(&b, "SMBIOS %d.%d.%d present.\n", t.MajorVersion, t.MinorVersion, t.DmiRevision)
+	} else {
+		// Only the 2.x entry point carries a structure count
+		fmt.Fprintf(&b, "SMBIOS %d.%d present.\n", t.MajorVersion, t.MinorVersion)
+		fmt.Fprintf(&b, "%d structures occupying %d bytes.\n", len(t.Structures), t.Length)
+	}
+	b.WriteString("\n")
+
+	ver := uint16(t.MajorVersion)<<8 | uint16(t.MinorVersion)
+	for i := range t.Structures {
+		s := &t.Structures[i]
+		fmt.Fprintf(&b, "Handle 0x%04X, DMI type %d, %d bytes\n", s.Handle, s.Type, s.Length)
+		renderStructure(dmiPrinter{b: &b, s: s}, ver)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// dmiPrinter writes the fields of one structure in dmidecode's layout. Its accessors
+// read 0 past the formatted area; the decoders check the length first, as dmidecode does.
+type dmiPrinter struct {
+	b *strings.Builder
+	s *Structure
+}
+
+func (p dmiPrinter) name(name string) {
+	p.b.WriteString(name + "\n")
+}
+
+func (p dmiPrinter) attr(label, format string, args ...any) {
+	fmt.Fprintf(p.b, "\t%s: %s\n", label, fmt.Sprintf(format, args...))
+}
+
+// str prints the string field at offset.
+func (p dmiPrinter) str(label string, offset int) {
+	p.attr(label, "%s", dmiString(p.s.stringField(offset)))
+}
+
+// listStart prints a list label; a non-empty summary goes on the label's line.
+func (p dmiPrinter) listStart(label, summary string) {
+	if summary == "" {
+		fmt.Fprintf(p.b, "\t%s:\n", label)
+		return
+	}
+	p.attr(label, "%s", summary)
+}
+
+func (p dmiPrinter) item(format string, args ...any) {
+	fmt.Fprintf(p.b, "\t\t%s\n", fmt.Sprintf(format, args...))
+}
+
+// flags prints the names of the bits set in v, one per list item, or "None".
+func (p dmiPrinter) flags(label string, v uint64, names []string, first int) {
+	var set []string
+	for i, name := range names {
+		if v&(1<<(first+i)) != 0 {
+			set = append(set, name)
+		}
+	}
+	if len(set) == 0 {
+		p.attr(label, "None")
+		return
+	}
+	p.listStart(label, "")
+	for _, name := range set {
+		p.item("%s", name)
+	}
+}
+
+func (p dmiPrinter) length() int {
+	return int(p.s.Length)
+}
+
+func (p dmiPrinter) byte(offset int) byte {
+	v, _ := p.s.Byte(offset)
+	return v
+}
+
+func (p dmiPrinter) word(offset int) uint16 {
+	v, _ := p.s.Word(offset)
+	return v
+}
+
+func (p dmiPrinter) dword(offset int) uint32 {
+	v, _ := p.s.DWord(offset)
+	return v
+}
+
+func (p dmiPrinter) qword(offset int) uint64 {
+	v, _ := p.s.QWord(offset)
+	return v
+}
+
+// stringAt resolves a string number rather than a string field.
+func (p dmiPrinter) stringAt(index int) string {
+	switch {
+	case index == 0:
+		return dmiString("")
+	case index > len(p.s.Strings):
+		return BadIndex
+	}
+	return dmiString(p.s.Strings[index-1])
+}
+
+func renderStructure(p dmiPrinter, ver uint16) {
+	s := p.s
+	// Type 10 names each device it holds
+	if s.Type == 10 {
+		dmiOnBoardDevices(p)
+		return
+	}
+
+	name, known := typeNames[s.Type]
+	switch {
+	case s.Type >= 128:
+		name = "OEM-specific Type"
+	case !known:
+		name = "Unknown Type"
+	}
+	p.name(name)
+
+	switch s.Type {
+	case TypeBIOSInformation:
+		dmiBIOS(p)
+	case TypeSystemInformation:
+		dmiSystem(p, ver)
+	case TypeBaseboard:
+		dmiBaseboard(p)
+	case TypeChassis:
+		dmiChassis(p)
+	case TypeProcessorInformation:
+		dmiProcessor(p, ver)
+	case 7:
+		dmiCache(p)
+	case 8:
+		dmiPortConnector(p)
+	case 9:
+		dmiSlot(p)
+	case TypeOEMStrings:
+		dmiStringList(p, "String")
+	case TypeConfigurationOptions:
+		dmiStringList(p, "Option")
+	case 13:
+		dmiBIOSLanguage(p, ver)
+	case 14:
+		dmiGroupAssociations(p)
+	case 16:
+		dmiMemoryArray(p)
+	case TypeMemoryDevice:
+		dmiMemoryDevice(p)
+	case 18:
+		dmiMemoryError(p)
+	case 19:
+		dmiMemoryArrayMappedAddress(p)
+	case 20:
+		dmiMemoryDeviceMappedAddress(p)
+	case 24:
+		dmiHardwareSecurity(p)
+	case 32:
+		dmiSystemBoot(p)
+	case 41:
+		dmiOnboardDevice(p)
+	case 43:
+		dmiTPMDevice(p)
+	case 126, TypeEndOfTable:
+	default:
+		dmiDump(p)
+	}
+}
+
+func dmiBIOS(p dmiPrinter) {
+	if p.length() < 0x12 {
+		return
+	}
+	p.str("Vendor", 0x04)
+	p.str("Version", 0x05)
+	p.str("Release Date", 0x08)
+	// UEFI firmware has no legacy BIOS segment
+	if segment := p.word(0x06); segment != 0 {
+		p.attr("Address", "0x%04X0", segment)
+		runtime := (0x10000 - uint32(segment)) << 4
+		if runtime&0x3FF == 0 {
+			p.attr("Runtime Size", "%d kB", runtime>>10)
+		} else {
+			p.attr("Runtime Size", "%d bytes", runtime)
+		}
+	}
+	if romSize := p.byte(0x09); romSize != 0xFF {
+		p.attr("ROM Size", "%s", dmiMemorySize((uint64(romSize)+1)<<6, 1))
+	} else {
+		// dmidecode assumes 16 MB when the extended field is missing
+		ext := uint16(16)
+		if p.length() >= 0x1A {
+			ext = p.word(0x18)
+		}
+		p.attr("ROM Size", "%d %s", ext&0x3FFF, [...]string{"MB", "GB", outOfSpec, outOfSpec}[ext>>14])
+	}
+
+	bios, _ := DecodeBIOSInformation(p.s)
+	p.listStart("Characteristics", "")
+	for _, name := range bios.CharacteristicNames() {
+		p.item("%s", name)
+	}
+	if p.length() < 0x18 {
+		return
+	}
+	if r := bios.BIOSRevision(); r != "" {
+		p.attr("BIOS Revision", "%s", r)
+	}
+	if r := bios.FirmwareRevision(); r != "" {
+		p.attr("Firmware Revision", "%s", r)
+	}
+}
+
+func dmiSystem(p dmiPrinter, ver uint16) {
+	if p.length() < 0x08 {
+		return
+	}
+	p.str("Manufacturer", 0x04)
+	p.str("Product Name", 0x05)
+	p.str("Version", 0x06)
+	p.str("Serial Number", 0x07)
+	if p.length() < 0x19 {
+		return
+	}
+	raw, _ := p.s.Bytes(0x08, 16)
+	uuid, _ := DecodeUUID(raw, byte(ver>>8), byte(ver))
+	// dmidecode's labels: all 0xFF is "Not Present", all zeros "Not Settable"
+	switch {
+	case uuid.IsNotSet():
+		p.attr("UUID", "Not Present")
+	case uuid.IsAbsent():
+		p.attr("UUID", "Not Settable")
+	default:
+		p.attr("UUID", "%s", uuid)
+	}
+	p.attr("Wake-up Type", "%s", dmiName(wakeUpTypes, int(p.byte(0x18))))
+	if p.length() < 0x1B {
+		return
+	}
+	p.str("SKU Number", 0x19)
+	p.str("Family", 0x1A)
+}
+
+func dmiBaseboard(p dmiPrinter) {
+	if p.length() < 0x08 {
+		return
+	}
+	p.str("Manufacturer", 0x04)
+	p.str("Product Name", 0x05)
+	p.str("Version", 0x06)
+	p.str("Serial Number", 0x07)
+	if p.length() < 0x09 {
+		return
+	}
+	p.str("Asset Tag", 0x08)
+	if p.length() < 0x0A {
+		return
+	}
+	p.flags("Features", uint64(p.byte(0x09)), baseboardFeatures, 0)
+	if p.length() < 0x0E {
+		return
+	}
+	p.str("Location In Chassis", 0x0A)
+	p.attr("Chassis Handle", "0x%04X", p.word(0x0B))
+	p.attr("Type", "%s", dmiName(boardTypes, int(p.byte(0x0D))))
+	if p.length() < 0x0F {
+		return
+	}
+	count := int(p.byte(0x0E))
+	if p.length() < 0x0F+2*count {
+		return
+	}
+	p.listStart("Contained Object Handles", strconv.Itoa(count))
+	for i := range count {
+		p.item("0x%04X", p.word(0x0F+2*i))
+	}
+}
+
+func dmiChassis(p dmiPrinter) {
+	if p.length() < 0x09 {
+		return
+	}
+	p.str("Manufacturer", 0x04)
+	typ := p.byte(0x05)
+	p.attr("Type", "%s", dmiName(chassisTypes, int(typ&0x7F)))
+	p.attr("Lock", "%s", [...]string{"Not Present", "Present"}[typ>>7])
+	p.str("Version", 0x06)
+	p.str("Serial Number", 0x07)
+	p.str("Asset Tag", 0x08)
+	if p.length() < 0x0D {
+		return
+	}
+	p.attr("Boot-up State", "%s", dmiName(chassisStates, int(p.byte(0x09))))
+	p.attr("Power Supply State", "%s", dmiName(chassisStates, int(p.byte(0x0A))))
+	p.attr("Thermal State", "%s", dmiName(chassisStates, int(p.byte(0x0B))))
+	p.attr("Security Status", "%s", dmiName(chassisSecurityStatuses, int(p.byte(0x0C))))
+	if p.length() < 0x11 {
+		return
+	}
+	p.attr("OEM Information", "0x%08X", p.dword(0x0D))
+	if p.length() < 0x13 {
+		return
+	}
+	if height := p.byte(0x11); height == 0 {
+		p.attr("Height", "Unspecified")
+	} else {
+		p.attr("Height", "%d U", height)
+	}
+	if cords := p.byte(0x12); cords == 0 {
+		p.attr("Number Of Power Cords", "Unspecified")
+	} else {
+		p.attr("Number Of Power Cords", "%d", cords)
+	}
+	if p.length() < 0x15 {
+		return
+	}
+	count, recordLength := int(p.byte(0x13)), int(p.byte(0x14))
+	if p.length() < 0x15+count*recordLength {
+		return
+	}
+	p.listStart("Contained Elements", strconv.Itoa(count))
+	for i := 0; i < count && recordLength >= 3; i++ {
+		record := 0x15 + i*recordLength
+		// Bit 7 selects a structure type rather than a board type
+		element := p.byte(record)
+		name := dmiName(boardTypes, int(element&0x7F))
+		if element&0x80 != 0 {
+			name = dmiStructureType(element & 0x7F)
+		}
+		if low, high := p.byte(record+1), p.byte(record+2); low == high {
+			p.item("%s (%d)", name, low)
+		} else {
+			p.item("%s (%d-%d)", name, low, high)
+		}
+	}
+	if p.length() < 0x16+count*recordLength {
+		return
+	}
+	p.str("SKU Number", 0x15+count*recordLength)
+}
+
+func dmiProcessor(p dmiPrinter, ver uint16) {
+	if p.length() < 0x1A {
+		return
+	}
+	p.str("Socket Designation", 0x04)
+	p.attr("Type", "%s", dmiName(processorTypes, int(p.byte(0x05))))
+	p.attr("Family", "%s", dmiProcessorFamily(p, ver))
+	p.str("Manufacturer", 0x07)
+	dmiProcessorID(p)
+	p.str("Version", 0x10)
+	p.attr("Voltage", "%s", dmiProcessorVoltage(p.byte(0x11)))
+	p.attr("External Clock", "%s", mhz(p.word(0x12)))
+	p.attr("Max Speed", "%s", mhz(p.word(0x14)))
+	p.attr("Current Speed", "%s", mhz(p.word(0x16)))
+	if status := p.byte(0x18); status&0x40 != 0 {
+		p.attr("Status", "Populated, %s", cpuStatus(status&0x07))
+	} else {
+		p.attr("Status", "Unpopulated")
+	}
+	p.attr("Upgrade", "%s", dmiName(processorUpgrades, int(p.byte(0x19))))
+	if p.length() < 0x20 {
+		return
+	}
+	for i, level := range []string{"L1", "L2", "L3"} {
+		switch handle := p.word(0x1A + 2*i); {
+		case handle != 0xFFFF:
+			p.attr(level+" Cache Handle", "0x%04X", handle)
+		case ver >= 0x0203:
+			p.attr(level+" Cache Handle", "Not Provided")
+		default:
+			p.attr(level+" Cache Handle", "No %s Cache", level)
+		}
+	}
+	if p.length() < 0x23 {
+		return
+	}
+	p.str("Serial Number", 0x20)
+	p.str("Asset Tag", 0x21)
+	p.str("Part Number", 0x22)
+	if p.length() < 0x28 {
+		return
+	}
+
+	// Counts above 254 are in the 3.0 "Count 2" words, when the structure has them
+	count := func(offset, offset2 int) uint16 {
+		if c := p.byte(offset); c != 0xFF || p.length() < offset2+2 {
+			return uint16(c)
+		}
+		return p.word(offset2)
+	}
+	if p.byte(0x23) != 0 {
+		p.attr("Core Count", "%d", count(0x23, 0x2A))
+	}
+	if p.byte(0x24) != 0 {
+		p.attr("Core Enabled", "%d", count(0x24, 0x2C))
+	}
+	if p.byte(0x25) != 0 {
+		p.attr("Thread Count", "%d", count(0x25, 0x2E))
+	}
+	if p.length() >= 0x32 && p.word(0x30) != 0 {
+		p.attr("Thread Enabled", "%d", p.word(0x30))
+	}
+	characteristics := p.word(0x26)
+	if characteristics&0x00FC == 0 {
+		p.attr("Characteristics", "None")
+		return
+	}
+	p.flags("Characteristics", uint64(characteristics), processorCharacteristics, 2)
+}
+
+// dmiFamilyCode returns the processor family, taking it from Processor Family 2 when
+// the byte is 0xFE.
+func dmiFamilyCode(p dmiPrinter) uint16 {
+	if family := p.byte(0x06); family != 0xFE || p.length() < 0x2A {
+		return uint16(family)
+	}
+	return p.word(0x28)
+}
+
+func dmiProcessorFamily(p dmiPrinter, ver uint16) string {
+	manufacturer := p.s.stringField(0x07)
+	is := func(vendor string) bool {
+		return strings.Contains(manufacturer, vendor) || strings.HasPrefix(strings.ToLower(manufacturer), strings.ToLower(vendor))
+	}
+
+	// 0x30 meant Pentium Pro to SMBIOS 2.0, and 0xBE is claimed by both Core 2 and K7
+	family := dmiFamilyCode(p)
+	switch {
+	case ver == 0x0200 && p.byte(0x06) == 0x30 && is("Intel"):
+		return "Pentium Pro"
+	case family == 0xBE && is("Intel"):
+		return "Core 2"
+	case family == 0xBE && is("AMD"):
+		return "K7"
+	case family == 0xBE:
+		return "Core 2 or K7"
+	}
+	if name, ok := processorFamilies[family]; ok {
+		return name
+	}
+	return outOfSpec
+}
+
+// dmiProcessorID prints the raw ID, then the signature and feature flags for the
+// families dmidecode knows how to read them for.
+func dmiProcessorID(p dmiPrinter) {
+	id, _ := p.s.Bytes(0x08, 8)
+	p.attr("ID", "% X", id)
+
+	family := dmiFamilyCode(p)
+	eax, dx := p.dword(0x08), p.word(0x08)
+	vendor := cpuidVendor(family)
+	switch {
+	case family == 0x05: // 80386
+		p.attr("Signature", "Type %d, Family %d, Major Stepping %d, Minor Stepping %d", dx>>12, dx>>8&0xF, dx>>4&0xF, dx&0xF)
+		return
+	case family == 0x06: // 80486, only some of which have CPUID
+		if dx&0x0F00 != 0x0400 || dx&0x00F0 != 0x0040 && dx&0x00F0 < 0x0070 || dx&0x000F < 0x0003 {
+			p.attr("Signature", "Type %d, Family %d, Model %d, Stepping %d", dx>>12&0x3, dx>>8&0xF, dx>>4&0xF, dx&0xF)
+			return
+		}
+		vendor = "Intel"
+	case family >= 0x100 && family <= 0x101, family >= 0x118 && family <= 0x119: // ARM
+		if p.length() >= 0x28 && p.word(0x26)&(1<<9) != 0 {
+			// SMCCC_ARCH_SOC_ID: the JEP-106 code, then the SoC revision
+			p.attr("Signature", "JEP-106 Bank 0x%02x Manufacturer 0x%02x, SoC ID 0x%04x, SoC Revision 0x%08x",
+				eax>>24&0x7F, eax>>16&0x7F, eax&0xFFFF, p.dword(0x0C))
+			return
+		}
+		// MIDR_EL1, which SMBIOS left undefined before 3.1.0
+		if eax != 0 {
+			p.attr("Signature", "Implementor 0x%02x, Variant 0x%x, Architecture %d, Part 0x%03x, Revision %d",
+				eax>>24, eax>>20&0xF, eax>>16&0xF, eax>>4&0xFFF, eax&0xF)
+		}
+		return
+	case family == 0x01 || family == 0x02:
+		// Some x86 processors report "Other" or "Unknown"; the version string gives them away
+		version := dmiString(p.s.stringField(0x10))
+		switch {
+		case strings.HasPrefix(version, "Pentium III MMX"),
+			strings.HasPrefix(version, "Intel(R) Core(TM)2"),
+			strings.HasPrefix(version, "Intel(R) Pentium(R)"),
+			version == "Genuine Intel(R) CPU U1400":
+			vendor = "Intel"
+		case strings.HasPrefix(version, "AMD Athlon(TM)"),
+			strings.HasPrefix(version, "AMD Opteron(tm)"),
+			strings.HasPrefix(version, "Dual-Core AMD Opteron(tm)"):
+			vendor = "AMD"
+		}
+	}
+
+	baseFamily, baseModel := eax>>8&0xF, eax>>4&0xF
+	switch vendor {
+	case "Intel":
+		p.attr("Signature", "Type %d, Family %d, Model %d, Stepping %d",
+			eax>>12&0x3, eax>>20&0xFF+baseFamily, eax>>12&0xF0+baseModel, eax&0xF)
+	case "AMD":
+		// The extended fields only count for base family 0xF
+		if baseFamily == 0xF {
+			baseFamily += eax >> 20 & 0xFF
+			baseModel |= eax >> 12 & 0xF0
+		}
+		p.attr("Signature", "Family %d, Model %d, Stepping %d", baseFamily, baseModel, eax&0xF)
+	default:
+		return
+	}
+
+	edx := p.dword(0x0C)
+	if edx&0xBFEFFBFF == 0 {
+		p.attr("Flags", "None")
+		return
+	}
+	p.listStart("Flags", "")
+	for bit, description := range cpuFeatureDescriptions {
+		if description != "" && edx&(1<<bit) != 0 {
+			p.item("%s", description)
+		}
+	}
+}
+
+func dmiProcessorVoltage(code byte) string {
+	if code&0x80 != 0 {
+		return fmt.Sprintf("%.1f V", float64(code&0x7F)/10)
+	}
+	if code&0x07 == 0 {
+		return "Unknown"
+	}
+	var voltages []string
+	for bit, voltage := range []string{"5.0 V", "3.3 V", "2.9 V"} {
+		if code&(1<<bit) != 0 {
+			voltages = append(voltages, voltage)
+		}
+	}
+	return strings.Join(voltages, " ")
+}
+
+func dmiCache(p dmiPrinter) {
+	if p.length() < 0x0F {
+		return
+	}
+	p.str("Socket Designation", 0x04)
+	config := p.word(0x05)
+	enabled, socketed := "Disabled", "Not Socketed"
+	if config&0x0080 != 0 {
+		enabled = "Enabled"
+	}
+	if config&0x0008 != 0 {
+		socketed = "Socketed"
+	}
+	p.attr("Configuration", "%s, %s, Level %d", enabled, socketed, config&0x0007+1)
+	p.attr("Operational Mode", "%s", cacheModes[config>>8&0x3])
+	p.attr("Location", "%s", dmiName(cacheLocations, int(config>>5&0x3)))
+
+	// The 3.1 "Size 2" dwords supersede the words when present
+	legacySize := func(offset int) uint32 {
+		size := p.word(offset)
+		return uint32(size&0x8000)<<16 | uint32(size&0x7FFF)
+	}
+	installed, maximum := legacySize(0x09), legacySize(0x07)
+	if p.length() >= 0x1B {
+		installed = p.dword(0x17)
+	}
+	if p.length() >= 0x17 {
+		maximum = p.dword(0x13)
+	}
+	p.attr("Installed Size", "%s", dmiCacheSize(installed))
+	p.attr("Maximum Size", "%s", dmiCacheSize(maximum))
+
+	if supported := p.word(0x0B); supported&0x7F == 0 {
+		p.attr("Supported SRAM Types", "None")
+	} else {
+		p.flags("Supported SRAM Types", uint64(supported), sramTypes, 0)
+	}
+	if installedType := p.word(0x0D); installedType&0x7F == 0 {
+		p.attr("Installed SRAM Type", "None")
+	} else {
+		var types []string
+		for bit, name := range sramTypes {
+			if installedType&(1<<bit) != 0 {
+				types = append(types, name)
+			}
+		}
+		p.attr("Installed SRAM Type", "%s", strings.Join(types, " "))
+	}
+	if p.length() < 0x13 {
+		return
+	}
+	if speed := p.byte(0x0F); speed == 0 {
+		p.attr("Speed", "Unknown")
+	} else {
+		p.attr("Speed", "%d ns", speed)
+	}
+	p.attr("Error Correction Type", "%s", dmiName(cacheErrorCorrectionTypes, int(p.byte(0x10))))
+	p.attr("System Type", "%s", dmiName(cacheSystemTypes, int(p.byte(0x11))))
+	p.attr("Associativity", "%s", dmiName(cacheAssociativities, int(p.byte(0x12))))
+}
+
+// dmiCacheSize formats a cache size dword: kB, or 64 kB units when bit 31 is set.
+func dmiCacheSize(code uint32) string {
+	if code&0x80000000 != 0 {
+		return dmiMemorySize(uint64(code&0x7FFFFFFF)<<6, 1)
+	}
+	return dmiMemorySize(uint64(code), 1)
+}
+
+func dmiPortConnector(p dmiPrinter) {
+	if p.length() < 0x09 {
+		return
+	}
+	p.str("Internal Reference Designator", 0x04)
+	p.attr("Internal Connector Type", "%s", dmiPortConnectorType(p.byte(0x05)))
+	p.str("External Reference Designator", 0x06)
+	p.attr("External Connector Type", "%s", dmiPortConnectorType(p.byte(0x07)))
+	p.attr("Port Type", "%s", dmiPortType(p.byte(0x08)))
+}
+
+func dmiPortConnectorType(code byte) string {
+	switch {
+	case code == 0xFF:
+		return "Other"
+	case code >= 0xA0:
+		return dmiName(portConnectorTypesA0, int(code)-0xA0)
+	}
+	return dmiName(portConnectorTypes, int(code))
+}
+
+func dmiPortType(code byte) string {
+	switch {
+	case code == 0xFF:
+		return "Other"
+	case code >= 0xA0:
+		return dmiName(portTypesA0, int(code)-0xA0)
+	}
+	return dmiName(portTypes, int(code))
+}
+
+func dmiSlot(p dmiPrinter) {
+	if p.length() < 0x0C {
+		return
+	}
+	typ := p.byte(0x05)
+	p.str("Designation", 0x04)
+	p.attr("Type", "%s%s", dmiSlotBusWidth(p.byte(0x06)), dmiSlotType(typ))
+	p.attr("Current Usage", "%s", dmiName(slotUsages, int(p.byte(0x07))))
+	p.attr("Length", "%s", dmiName(slotLengths, int(p.byte(0x08))))
+	switch {
+	case typ == 0x04 || typ == 0x05, // MCA, EISA
+		typ == 0x06 || typ >= 0x0E && typ <= 0x13,                               // PCI, AGP, PCI-X
+		typ >= 0x26 && typ <= 0x28 || typ == 0x30 || typ == 0xC5 || typ == 0xC6, // OCP NIC, CXL, EDSFF
+		slotIsPCIe(typ):
+		p.attr("ID", "%d", p.byte(0x09))
+	case typ == 0x07: // PCMCIA
+		p.attr("ID", "Adapter %d, Socket %d", p.byte(0x09), p.byte(0x0A))
+	}
+
+	characteristics1, characteristics2 := p.byte(0x0B), p.byte(0x0C)
+	if p.length() < 0x0D {
+		characteristics2 = 0
+	}
+	switch {
+	case characteristics1&0x01 != 0:
+		p.attr("Characteristics", "Unknown")
+	case characteristics1&0xFE == 0 && characteristics2 == 0:
+		p.attr("Characteristics", "None")
+	default:
+		p.listStart("Characteristics", "")
+		for bit, name := range slotCharacteristics1 {
+			if characteristics1&(1<<(bit+1)) != 0 {
+				p.item("%s", name)
+			}
+		}
+		for bit, name := range slotCharacteristics2 {
+			if characteristics2&(1<<bit) != 0 {
+				p.item("%s", name)
+			}
+		}
+	}
+	if p.length() < 0x11 {
+		return
+	}
+	dmiBusAddress(p, 0x0D)
+	if p.length() < 0x13 {
+		return
+	}
+	peers := int(p.byte(0x12))
+	p.attr("Data Bus Width", "%d", p.byte(0x11))
+	p.attr("Peer Devices", "%d", peers)
+	if p.length()-0x13 >= 5*peers {
+		for i := range peers {
+			peer := 0x13 + 5*i
+			devfn := p.byte(peer + 3)
+			p.attr(fmt.Sprintf("Peer Device %d", i+1), "%04x:%02x:%02x.%x (Width %d)",
+				p.word(peer), p.byte(peer+2), devfn>>3, devfn&0x7, p.byte(peer+4))
+		}
+	}
+
+	next := 0x13 + 5*peers
+	if p.length() < next+4 {
+		return
+	}
+	if generation := p.byte(next); generation != 0 && slotIsPCIe(typ) {
+		p.attr("PCI Express Generation", "%d", generation)
+	}
+	if width := p.byte(next + 1); width != 0 {
+		p.attr("Slot Physical Width", "%s", strings.TrimSpace(dmiSlotBusWidth(width)))
+	}
+	if pitch := p.word(next + 2); pitch != 0 {
+		p.attr("Pitch", "%d.%02d mm", pitch/100, pitch%100)
+	}
+	if p.length() < next+5 {
+		return
+	}
+	p.attr("Height", "%s", dmiName(slotHeights, int(p.byte(next+4))))
+}
+
+// dmiSlotBusWidth returns the prefix for the slot type; "Other" and "Unknown" add none.
+func dmiSlotBusWidth(code byte) string {
+	if code == 0x01 || code == 0x02 {
+		return ""
+	}
+	return dmiName(slotBusWidths, int(code))
+}
+
+func dmiSlotType(code byte) string {
+	switch {
+	case code == 0x30:
+		return "CXL FLexbus 1.0"
+	case code >= 0xA0:
+		return dmiName(slotTypesA0, int(code)-0xA0)
+	}
+	return dmiName(slotTypes, int(code))
+}
+
+func slotIsPCIe(code byte) bool {
+	return code >= 0x1F && code <= 0x25 || code >= 0xA5 && code <= 0xC4 && code != 0xB7
+}
+
+// dmiBusAddress prints the segment, bus and device/function at offset unless they are
+// all ones.
+func dmiBusAddress(p dmiPrinter, offset int) {
+	segment, bus, devfn := p.word(offset), p.byte(offset+2), p.byte(offset+3)
+	if segment == 0xFFFF && bus == 0xFF && devfn == 0xFF {
+		return
+	}
+	p.attr("Bus Address", "%04x:%02x:%02x.%x", segment, bus, devfn>>3, devfn&0x7)
+}
+
+func dmiOnBoardDevices(p dmiPrinter) {
+	count := (p.length() - 0x04) / 2
+	for i := range count {
+		if count == 1 {
+			p.name(typeNames[10])
+		} else {
+			p.name(fmt.Sprintf("On Board Device %d Information", i+1))
+		}
+		device := p.byte(0x04 + 2*i)
+		p.attr("Type", "%s", dmiName(onBoardDeviceTypes, int(device&0x7F)))
+		p.attr("Status", "%s", dmiEnabled(device&0x80 != 0))
+		p.str("Description", 0x05+2*i)
+	}
+}
+
+// dmiStringList prints Type 11 and 12, which number their strings rather than
+// pointing fields at them.
+func dmiStringList(p dmiPrinter, label string) {
+	if p.length() < 0x05 {
+		return
+	}
+	for i := 1; i <= int(p.byte(0x04)); i++ {
+		p.attr(fmt.Sprintf("%s %d", label, i), "%s", p.stringAt(i))
+	}
+}
+
+func dmiBIOSLanguage(p dmiPrinter, ver uint16) {
+	if p.length() < 0x16 {
+		return
+	}
+	if ver >= 0x0201 {
+		format := "Long"
+		if p.byte(0x05)&0x01 != 0 {
+			format = "Abbreviated"
+		}
+		p.attr("Language Description Format", "%s", format)
+	}
+	count := int(p.byte(0x04))
+	p.listStart("Installable Languages", strconv.Itoa(count))
+	for i := 1; i <= count; i++ {
+		p.item("%s", p.stringAt(i))
+	}
+	p.str("Currently Installed Language", 0x15)
+}
+
+func dmiGroupAssociations(p dmiPrinter) {
+	if p.length() < 0x05 {
+		return
+	}
+	p.str("Name", 0x04)
+	count := (p.length() - 0x05) / 3
+	p.listStart("Items", strconv.Itoa(count))
+	for i := range count {
+		item := 0x05 + 3*i
+		p.item("0x%04X (%s)", p.word(item+1), dmiStructureType(p.byte(item)))
+	}
+}
+
+func dmiMemoryArray(p dmiPrinter) {
+	if p.length() < 0x0F {
+		return
+	}
+	if location := p.byte(0x04); location >= 0xA0 {
+		p.attr("Location", "%s", dmiName(memoryArrayLocationsA0, int(location)-0xA0))
+	} else {
+		p.attr("Location", "%s", dmiName(memoryArrayLocations, int(location)))
+	}
+	p.attr("Use", "%s", dmiName(memoryArrayUses, int(p.byte(0x05))))
+	p.attr("Error Correction Type", "%s", dmiName(memoryArrayErrorCorrectionTypes, int(p.byte(0x06))))
+	// 0x80000000 defers to the 2.7 Extended Maximum Capacity qword, in bytes
+	switch capacity := p.dword(0x07); {
+	case capacity != 0x80000000:
+		p.attr("Maximum Capacity", "%s", dmiMemorySize(uint64(capacity), 1))
+	case p.length() < 0x17:
+		p.attr("Maximum Capacity", "Unknown")
+	default:
+		p.attr("Maximum Capacity", "%s", dmiMemorySize(p.qword(0x0F), 0))
+	}
+	p.attr("Error Information Handle", "%s", dmiErrorHandle(p.word(0x0B)))
+	p.attr("Number Of Devices", "%d", p.word(0x0D))
+}
+
+func dmiMemoryDevice(p dmiPrinter) {
+	if p.length() < 0x15 {
+		return
+	}
+	p.attr("Array Handle", "0x%04X", p.word(0x04))
+	p.attr("Error Information Handle", "%s", dmiErrorHandle(p.word(0x06)))
+	p.attr("Total Width", "%s", bitWidth(p.word(0x08)))
+	p.attr("Data Width", "%s", bitWidth(p.word(0x0A)))
+
+	size := p.word(0x0C)
+	switch {
+	case size == 0x7FFF && p.length() >= 0x20:
+		// Extended Size, in MB, shown in the largest unit that keeps it exact
+		ext := p.dword(0x1C) & 0x7FFFFFFF
+		switch {
+		case ext&0x3FF != 0:
+			p.attr("Size", "%d MB", ext)
+		case ext&0xFFC00 != 0:
+			p.attr("Size", "%d GB", ext>>10)
+		default:
+			p.attr("Size", "%d TB", ext>>20)
+		}
+	case size == 0:
+		p.attr("Size", "No Module Installed")
+	case size == 0xFFFF:
+		p.attr("Size", "Unknown")
+	default:
+		// In kB when bit 15 is set, otherwise MB
+		kb := uint64(size & 0x7FFF)
+		if size&0x8000 == 0 {
+			kb <<= 10
+		}
+		p.attr("Size", "%s", dmiMemorySize(kb, 1))
+	}
+	p.attr("Form Factor", "%s", dmiName(memoryFormFactors, int(p.byte(0x0E))))
+	switch set := p.byte(0x0F); set {
+	case 0:
+		p.attr("Set", "None")
+	case 0xFF:
+		p.attr("Set", "Unknown")
+	default:
+		p.attr("Set", "%d", set)
+	}
+	p.str("Locator", 0x10)
+	p.str("Bank Locator", 0x11)
+	p.attr("Type", "%s", dmiName(memoryTypes, int(p.byte(0x12))))
+	if detail := p.word(0x13); detail&0xFFFE == 0 {
+		p.attr("Type Detail", "None")
+	} else {
+		var details []string
+		for bit := 1; bit < len(memoryTypeDetails); bit++ {
+			if detail&(1<<bit) != 0 {
+				details = append(details, memoryTypeDetails[bit])
+			}
+		}
+		p.attr("Type Detail", "%s", strings.Join(details, " "))
+	}
+	// The remaining fields mean nothing for an empty socket
+	if p.length() < 0x17 || size == 0 {
+		return
+	}
+
+	var extSpeed, extConfiguredSpeed uint32
+	if p.length() >= 0x5C {
+		extSpeed, extConfiguredSpeed = p.dword(0x54), p.dword(0x58)
+	}
+	p.attr("Speed", "%s", dmiMemorySpeed(p.word(0x15), extSpeed))
+	if p.length() < 0x1B {
+		return
+	}
+	p.str("Manufacturer", 0x17)
+	p.str("Serial Number", 0x18)
+	p.str("Asset Tag", 0x19)
+	p.str("Part Number", 0x1A)
+	if p.length() < 0x1C {
+		return
+	}
+	if rank := p.byte(0x1B) & 0x0F; rank == 0 {
+		p.attr("Rank", "Unknown")
+	} else {
+		p.attr("Rank", "%d", rank)
+	}
+	if p.length() < 0x22 {
+		return
+	}
+	p.attr("Configured Memory Speed", "%s", dmiMemorySpeed(p.word(0x20), extConfiguredSpeed))
+	if p.length() < 0x28 {
+		return
+	}
+	p.attr("Minimum Voltage", "%s", dmiMemoryVoltage(p.word(0x22)))
+	p.attr("Maximum Voltage", "%s", dmiMemoryVoltage(p.word(0x24)))
+	p.attr("Configured Voltage", "%s", dmiMemoryVoltage(p.word(0x26)))
+	if p.length() < 0x34 {
+		return
+	}
+	p.attr("Memory Technology", "%s", dmiName(memoryTechnologies, int(p.byte(0x28))))
+	if modes := p.word(0x29); modes&0xFFFE == 0 {
+		p.attr("Memory Operating Mode Capability", "None")
+	} else {
+		var names []string
+		for bit := 1; bit < len(memoryOperatingModes); bit++ {
+			if modes&(1<<bit) != 0 {
+				names = append(names, memoryOperatingModes[bit])
+			}
+		}
+		p.attr("Memory Operating Mode Capability", "%s", strings.Join(names, " "))
+	}
+	p.str("Firmware Version", 0x2B)
+	p.attr("Module Manufacturer ID", "%s", dmiManufacturerID(p.word(0x2C)))
+	p.attr("Module Product ID", "%s", dmiProductID(p.word(0x2E)))
+	p.attr("Memory Subsystem Controller Manufacturer ID", "%s", dmiManufacturerID(p.word(0x30)))
+	p.attr("Memory Subsystem Controller Product ID", "%s", dmiProductID(p.word(0x32)))
+	for _, size := range []struct {
+		label  string
+		offset int
+	}{
+		{"Non-Volatile Size", 0x34},
+		{"Volatile Size", 0x3C},
+		{"Cache Size", 0x44},
+		{"Logical Size", 0x4C},
+	} {
+		if p.length() < size.offset+8 {
+			return
+		}
+		switch v := p.qword(size.offset); v {
+		case 0:
+			p.attr(size.label, "None")
+		case 0xFFFFFFFFFFFFFFFF:
+			p.attr(size.label, "Unknown")
+		default:
+			p.attr(size.label, "%s", dmiMemorySize(v, 0))
+		}
+	}
+}
+
+func dmiMemorySpeed(code uint16, ext uint32) string {
+	switch {
+	case code == 0xFFFF && ext != 0:
+		return fmt.Sprintf("%d MT/s", ext)
+	case code == 0xFFFF || code == 0:
+		return "Unknown"
+	}
+	return fmt.Sprintf("%d MT/s", code)
+}
+
+// dmiMemoryVoltage formats millivolts the way dmidecode's float printf calls do.
+func dmiMemoryVoltage(mv uint16) string {
+	if mv == 0 {
+		return "Unknown"
+	}
+	v := float64(float32(mv) / 1000)
+	if mv%100 != 0 {
+		return fmt.Sprintf("%.6g V", v)
+	}
+	return fmt.Sprintf("%.1f V", v)
+}
+
+// dmiManufacturerID formats a JEDEC JEP-106 ID: the continuation count with odd
+// parity in the low byte, the manufacturer code in the high byte.
+func dmiManufacturerID(code uint16) string {
+	if code == 0 {
+		return "Unknown"
+	}
+	return fmt.Sprintf("Bank %d, Hex 0x%02X", code&0x7F+1, code>>8)
+}
+
+func dmiProductID(code uint16) string {
+	if code == 0 {
+		return "Unknown"
+	}
+	return fmt.Sprintf("0x%04X", code)
+}
+
+func dmiMemoryError(p dmiPrinter) {
+	if p.length() < 0x17 {
+		return
+	}
+	p.attr("Type", "%s", dmiName(memoryErrorTypes, int(p.byte(0x04))))
+	p.attr("Granularity", "%s", dmiName(memoryErrorGranularities, int(p.byte(0x05))))
+	p.attr("Operation", "%s", dmiName(memoryErrorOperations, int(p.byte(0x06))))
+	if syndrome := p.dword(0x07); syndrome == 0 {
+		p.attr("Vendor Syndrome", "Unknown")
+	} else {
+		p.attr("Vendor Syndrome", "0x%08X", syndrome)
+	}
+	for _, address := range []struct {
+		label  string
+		offset int
+	}{
+		{"Memory Array Address", 0x0B},
+		{"Device Address", 0x0F},
+		{"Resolution", 0x13},
+	} {
+		if v := p.dword(address.offset); v == 0x80000000 {
+			p.attr(address.label, "Unknown")
+		} else {
+			p.attr(address.label, "0x%08X", v)
+		}
+	}
+}
+
+func dmiMemoryArrayMappedAddress(p dmiPrinter) {
+	if p.length() < 0x0F {
+		return
+	}
+	dmiMappedAddress(p, 0x1F, 0x0F)
+	p.attr("Physical Array Handle", "0x%04X", p.word(0x0C))
+	p.attr("Partition Width", "%d", p.byte(0x0E))
+}
+
+func dmiMemoryDeviceMappedAddress(p dmiPrinter) {
+	if p.length() < 0x13 {
+		return
+	}
+	dmiMappedAddress(p, 0x23, 0x13)
+	p.attr("Physical Device Handle", "0x%04X", p.word(0x0C))
+	p.attr("Memory Array Mapped Address Handle", "0x%04X", p.word(0x0E))
+	switch row := p.byte(0x10); row {
+	case 0:
+		p.attr("Partition Row Position", "%s", outOfSpec)
+	case 0xFF:
+		p.attr("Partition Row Position", "Unknown")
+	default:
+		p.attr("Partition Row Position", "%d", row)
+	}
+	for _, position := range []struct {
+		label  string
+		offset int
+	}{
+		{"Interleave Position", 0x11},
+		{"Interleaved Data Depth", 0x12},
+	} {
+		switch v := p.byte(position.offset); v {
+		case 0:
+		case 0xFF:
+			p.attr(position.label, "Unknown")
+		default:
+			p.attr(position.label, "%d", v)
+		}
+	}
+}
+
+// dmiMappedAddress prints the start and end of a Type 19 or 20 range: kB dwords at
+// 0x04 and 0x08, or byte qwords at extOffset when the structure is at least extLength
+// long and the start dword is all ones.
+func dmiMappedAddress(p dmiPrinter, extLength, extOffset int) {
+	if p.length() >= extLength && p.dword(0x04) == 0xFFFFFFFF {
+		start, end := p.qword(extOffset), p.qword(extOffset+8)
+		p.attr("Starting Address", "0x%016X", start)
+		p.attr("Ending Address", "0x%016X", end)
+		if start == end {
+			p.attr("Range Size", "Invalid")
+		} else {
+			p.attr("Range Size", "%s", dmiMemorySize(end-start+1, 0))
+		}
+		return
+	}
+
+	start, end := p.dword(0x04), p.dword(0x08)
+	p.attr("Starting Address", "0x%08X%03X", start>>2, (start&0x3)<<10)
+	p.attr("Ending Address", "0x%08X%03X", end>>2, (end&0x3)<<10+0x3FF)
+	if size := end - start + 1; size == 0 {
+		p.attr("Range Size", "Invalid")
+	} else {
+		p.attr("Range Size", "%s", dmiMemorySize(uint64(size), 1))
+	}
+}
+
+func dmiHardwareSecurity(p dmiPrinter) {
+	if p.length() < 0x05 {
+		return
+	}
+	settings := p.byte(0x04)
+	p.attr("Power-On Password Status", "%s", hardwareSecurityStatuses[settings>>6])
+	p.attr("Keyboard Password Status", "%s", hardwareSecurityStatuses[settings>>4&0x3])
+	p.attr("Administrator Password Status", "%s", hardwareSecurityStatuses[settings>>2&0x3])
+	p.attr("Front Panel Reset Status", "%s", hardwareSecurityStatuses[settings&0x3])
+}
+
+func dmiSystemBoot(p dmiPrinter) {
+	if p.length() < 0x0B {
+		return
+	}
+	switch status := p.byte(0x0A); {
+	case status >= 192:
+		p.attr("Status", "Product-specific")
+	case status >= 128:
+		p.attr("Status", "OEM-specific")
+	default:
+		p.attr("Status", "%s", dmiName(bootStatuses, int(status)))
+	}
+}
+
+func dmiOnboardDevice(p dmiPrinter) {
+	if p.length() < 0x0B {
+		return
+	}
+	device := p.byte(0x05)
+	p.str("Reference Designation", 0x04)
+	p.attr("Type", "%s", dmiName(onBoardDeviceTypes, int(device&0x7F)))
+	p.attr("Status", "%s", dmiEnabled(device&0x80 != 0))
+	p.attr("Type Instance", "%d", p.byte(0x06))
+	dmiBusAddress(p, 0x07)
+}
+
+func dmiTPMDevice(p dmiPrinter) {
+	if p.length() < 0x1B {
+		return
+	}
+	vendor, _ := p.s.Bytes(0x04, 4)
+	if end := strings.IndexByte(string(vendor), 0); end >= 0 {
+		vendor = vendor[:end]
+	}
+	p.attr("Vendor ID", "%s", dmiString(string(vendor)))
+	p.attr("Specification Version", "%d.%d", p.byte(0x08), p.byte(0x09))
+	switch p.byte(0x08) {
+	case 0x01:
+		// The first two bytes repeat the specification version
+		p.attr("Firmware Revision", "%d.%d", p.byte(0x0C), p.byte(0x0D))
+	case 0x02:
+		revision := p.dword(0x0A)
+		p.attr("Firmware Revision", "%d.%d", revision>>16, revision&0xFFFF)
+	}
+	p.str("Description", 0x12)
+	p.listStart("Characteristics", "")
+	// Bit 2 means the other bits carry no information
+	if characteristics := p.qword(0x13); characteristics&(1<<2) != 0 {
+		p.item("%s", tpmCharacteristics[0])
+	} else {
+		for bit := 3; bit <= 5; bit++ {
+			if characteristics&(1<<bit) != 0 {
+				p.item("%s", tpmCharacteristics[bit-2])
+			}
+		}
+	}
+	if p.length() < 0x1F {
+		return
+	}
+	p.attr("OEM-specific Information", "0x%08X", p.dword(0x1B))
+}
+
+// dmiDump prints a structure the way dmidecode prints types it does not decode.
+func dmiDump(p dmiPrinter) {
+	p.listStart("Header and Data", "")
+	formatted := p.s.Formatted
+	for i := 0; i < len(formatted); i += 16 {
+		p.item("% X", formatted[i:min(i+16, len(formatted))])
+	}
+	if len(p.s.Strings) > 0 {
+		p.listStart("Strings", "")
+		for _, v := range p.s.Strings {
+			p.item("%s", dmiString(v))
+		}
+	}
+}
+
+// dmiString renders a decoded string field the way dmidecode prints it, with control
+// characters replaced so a string cannot break the layout.
+func dmiString(v string) string {
+	if v == "" {
+		return "Not Specified"
+	}
+	b := []byte(v)
+	for i, c := range b {
+		if c < 32 || c == 127 {
+			b[i] = '.'
+		}
+	}
+	return string(b)
+}
+
+// dmiName looks code up in a table indexed by code; codes past the end and empty
+// entries are out of spec.
+func dmiName(names []string, code int) string {
+	if code >= 0 && code < len(names) && names[code] != "" {
+		return names[code]
+	}
+	return outOfSpec
+}
+
+// dmiStructureType names a structure type referred to by another structure.
+func dmiStructureType(code byte) string {
+	if code >= 128 {
+		return "OEM-specific"
+	}
+	return dmiName(structureTypes, int(code))
+}
+
+func dmiEnabled(enabled bool) string {
+	if enabled {
+		return "Enabled"
+	}
+	return "Disabled"
+}
+
+func dmiErrorHandle(handle uint16) string {
+	switch handle {
+	case 0xFFFE:
+		return "Not Provided"
+	case 0xFFFF:
+		return "No Error"
+	}
+	return fmt.Sprintf("0x%04X", handle)
+}
+
+// dmiMemorySize is dmidecode's dmi_print_memory_size: code counts units of 1024^shift
+// bytes and is shown in the largest unit that holds it exactly, or in the unit below
+// when that one is needed too.
+func dmiMemorySize(code uint64, shift int) string {
+	units := [...]string{"bytes", "kB", "MB", "GB", "TB", "PB", "EB", "ZB"}
+	var split [7]uint64
+	for i := range 6 {
+		split[i] = code >> (10 * i) & 0x3FF
+	}
+	split[6] = code >> 60
+
+	i := 6
+	for i > 0 && split[i] == 0 {
+		i--
+	}
+	capacity := split[i]
+	if i > 0 && split[i-1] != 0 {
+		i--
+		capacity = split[i] + split[i+1]<<10
+	}
+	return fmt.Sprintf("%d %s", capacity, units[i+shift])
+}
+
+func mhz(v uint16) string {
+	if v == 0 {
+		return "Unknown"
+	}
+	return fmt.Sprintf("%d MHz", v)
+}
+
+func bitWidth(v uint16) string {
+	if v == 0 || v == 0xFFFF {
+		return "Unknown"
+	}
+	return fmt.Sprintf("%d bits", v)
+}
+
+func cpuStatus(v byte) string {
+	switch v {
+	case 0:
+		return "Unknown"
+	case 1:
+		return "Enabled"
+	case 2:
+		return "Disabled By User"
+	case 3:
+		return "Disabled By BIOS"
+	case 4:
+		return "Idle"
+	case 7:
+		return "Other"
+	}
+	return outOfSpec
+}
@@ -0,0 +1,973 @@
+package smbios
+
+// The name tables below are dmidecode 3.5's. Tables indexed directly by code have
+// "" for the codes dmidecode prints as out of spec.
+
+// typeNames are dmidecode's titles for each structure type.
+var typeNames = map[uint8]string{
+	0:   "BIOS Information",
+	1:   "System Information",
+	2:   "Base Board Information",
+	3:   "Chassis Information",
+	4:   "Processor Information",
+	5:   "Memory Controller Information",
+	6:   "Memory Module Information",
+	7:   "Cache Information",
+	8:   "Port Connector Information",
+	9:   "System Slot Information",
+	10:  "On Board Device Information",
+	11:  "OEM Strings",
+	12:  "System Configuration Options",
+	13:  "BIOS Language Information",
+	14:  "Group Associations",
+	15:  "System Event Log",
+	16:  "Physical Memory Array",
+	17:  "Memory Device",
+	18:  "32-bit Memory Error Information",
+	19:  "Memory Array Mapped Address",
+	20:  "Memory Device Mapped Address",
+	21:  "Built-in Pointing Device",
+	22:  "Portable Battery",
+	23:  "System Reset",
+	24:  "Hardware Security",
+	25:  "System Power Controls",
+	26:  "Voltage Probe",
+	27:  "Cooling Device",
+	28:  "Temperature Probe",
+	29:  "Electrical Current Probe",
+	30:  "Out-of-band Remote Access",
+	31:  "Boot Integrity Services Entry Point",
+	32:  "System Boot Information",
+	33:  "64-bit Memory Error Information",
+	34:  "Management Device",
+	35:  "Management Device Component",
+	36:  "Management Device Threshold Data",
+	37:  "Memory Channel",
+	38:  "IPMI Device Information",
+	39:  "System Power Supply",
+	40:  "Additional Information",
+	41:  "Onboard Device",
+	42:  "Management Controller Host Interface",
+	43:  "TPM Device",
+	44:  "Processor Additional Information",
+	45:  "Firmware Inventory Information",
+	46:  "String Property",
+	126: "Inactive",
+	127: "End Of Table",
+}
+
+// structureTypes are the short type names dmidecode uses when one structure refers to
+// another (chassis contained elements, group association items).
+var structureTypes = []string{
+	"BIOS",
+	"System",
+	"Base Board",
+	"Chassis",
+	"Processor",
+	"Memory Controller",
+	"Memory Module",
+	"Cache",
+	"Port Connector",
+	"System Slots",
+	"On Board Devices",
+	"OEM Strings",
+	"Configuration Options",
+	"BIOS Language",
+	"Group Associations",
+	"System Event Log",
+	"Physical Memory Array",
+	"Memory Device",
+	"32-bit Memory Error",
+	"Memory Array Mapped Address",
+	"Memory Device Mapped Address",
+	"Built-in Pointing Device",
+	"Portable Battery",
+	"System Reset",
+	"Hardware Security",
+	"System Power Controls",
+	"Voltage Probe",
+	"Cooling Device",
+	"Temperature Probe",
+	"Electrical Current Probe",
+	"Out-of-band Remote Access",
+	"Boot Integrity Services",
+	"System Boot",
+	"64-bit Memory Error",
+	"Management Device",
+	"Management Device Component",
+	"Management Device Threshold Data",
+	"Memory Channel",
+	"IPMI Device",
+	"Power Supply",
+	"Additional Information",
+	"Onboard Device",
+	"Management Controller Host Interface",
+	"TPM Device",
+	"Processor",
+	"Firmware",
+	"String Property",
+}
+
+// cpuFeatureDescriptions are dmidecode's labels for the CPUID leaf 1 EDX bits.
+var cpuFeatureDescriptions = [32]string{
+	"FPU (Floating-point unit on-chip)",
+	"VME (Virtual mode extension)",
+	"DE (Debugging extension)",
+	"PSE (Page size extension)",
+	"TSC (Time stamp counter)",
+	"MSR (Model specific registers)",
+	"PAE (Physical address extension)",
+	"MCE (Machine check exception)",
+	"CX8 (CMPXCHG8 instruction supported)",
+	"APIC (On-chip APIC hardware supported)",
+	"",
+	"SEP (Fast system call)",
+	"MTRR (Memory type range registers)",
+	"PGE (Page global enable)",
+	"MCA (Machine check architecture)",
+	"CMOV (Conditional move instruction supported)",
+	"PAT (Page attribute table)",
+	"PSE-36 (36-bit page size extension)",
+	"PSN (Processor serial number present and enabled)",
+	"CLFSH (CLFLUSH instruction supported)",
+	"",
+	"DS (Debug store)",
+	"ACPI (ACPI supported)",
+	"MMX (MMX technology supported)",
+	"FXSR (FXSAVE and FXSTOR instructions supported)",
+	"SSE (Streaming SIMD extensions)",
+	"SSE2 (Streaming SIMD extensions 2)",
+	"SS (Self-snoop)",
+	"HTT (Multi-threading)",
+	"TM (Thermal monitor supported)",
+	"",
+	"PBE (Pending break enabled)",
+}
+
+var processorTypes = []string{
+	"",
+	"Other",
+	"Unknown",
+	"Central Processor",
+	"Math Processor",
+	"DSP Processor",
+	"Video Processor",
+}
+
+// processorFamilies is the full family table; 0xBE (Core 2 or K7) is resolved from
+// the manufacturer string instead.
+var processorFamilies = map[uint16]string{
+	0x01: "Other",
+	0x02: "Unknown",
+	0x03: "8086",
+	0x04: "80286",
+	0x05: "80386",
+	0x06: "80486",
+	0x07: "8087",
+	0x08: "80287",
+	0x09: "80387",
+	0x0A: "80487",
+	0x0B: "Pentium",
+	0x0C: "Pentium Pro",
+	0x0D: "Pentium II",
+	0x0E: "Pentium MMX",
+	0x0F: "Celeron",
+	0x10: "Pentium II Xeon",
+	0x11: "Pentium III",
+	0x12: "M1",
+	0x13: "M2",
+	0x14: "Celeron M",
+	0x15: "Pentium 4 HT",
+	0x16: "Intel",
+
+	0x18: "Duron",
+	0x19: "K5",
+	0x1A: "K6",
+	0x1B: "K6-2",
+	0x1C: "K6-3",
+	0x1D: "Athlon",
+	0x1E: "AMD29000",
+	0x1F: "K6-2+",
+	0x20: "Power PC",
+	0x21: "Power PC 601",
+	0x22: "Power PC 603",
+	0x23: "Power PC 603+",
+	0x24: "Power PC 604",
+	0x25: "Power PC 620",
+	0x26: "Power PC x704",
+	0x27: "Power PC 750",
+	0x28: "Core Duo",
+	0x29: "Core Duo Mobile",
+	0x2A: "Core Solo Mobile",
+	0x2B: "Atom",
+	0x2C: "Core M",
+	0x2D: "Core m3",
+	0x2E: "Core m5",
+	0x2F: "Core m7",
+	0x30: "Alpha",
+	0x31: "Alpha 21064",
+	0x32: "Alpha 21066",
+	0x33: "Alpha 21164",
+	0x34: "Alpha 21164PC",
+	0x35: "Alpha 21164a",
+	0x36: "Alpha 21264",
+	0x37: "Alpha 21364",
+	0x38: "Turion II Ultra Dual-Core Mobile M",
+	0x39: "Turion II Dual-Core Mobile M",
+	0x3A: "Athlon II Dual-Core M",
+	0x3B: "Opteron 6100",
+	0x3C: "Opteron 4100",
+	0x3D: "Opteron 6200",
+	0x3E: "Opteron 4200",
+	0x3F: "FX",
+	0x40: "MIPS",
+	0x41: "MIPS R4000",
+	0x42: "MIPS R4200",
+	0x43: "MIPS R4400",
+	0x44: "MIPS R4600",
+	0x45: "MIPS R10000",
+	0x46: "C-Series",
+	0x47: "E-Series",
+	0x48: "A-Series",
+	0x49: "G-Series",
+	0x4A: "Z-Series",
+	0x4B: "R-Series",
+	0x4C: "Opteron 4300",
+	0x4D: "Opteron 6300",
+	0x4E: "Opteron 3300",
+	0x4F: "FirePro",
+	0x50: "SPARC",
+	0x51: "SuperSPARC",
+	0x52: "MicroSPARC II",
+	0x53: "MicroSPARC IIep",
+	0x54: "UltraSPARC",
+	0x55: "UltraSPARC II",
+	0x56: "UltraSPARC IIi",
+	0x57: "UltraSPARC III",
+	0x58: "UltraSPARC IIIi",
+
+	0x60: "68040",
+	0x61: "68xxx",
+	0x62: "68000",
+	0x63: "68010",
+	0x64: "68020",
+	0x65: "68030",
+	0x66: "Athlon X4",
+	0x67: "Opteron X1000",
+	0x68: "Opteron X2000",
+	0x69: "Opteron A-Series",
+	0x6A: "Opteron X3000",
+	0x6B: "Zen",
+
+	0x70: "Hobbit",
+
+	0x78: "Crusoe TM5000",
+	0x79: "Crusoe TM3000",
+	0x7A: "Efficeon TM8000",
+
+	0x80: "Weitek",
+
+	0x82: "Itanium",
+	0x83: "Athlon 64",
+	0x84: "Opteron",
+	0x85: "Sempron",
+	0x86: "Turion 64",
+	0x87: "Dual-Core Opteron",
+	0x88: "Athlon 64 X2",
+	0x89: "Turion 64 X2",
+	0x8A: "Quad-Core Opteron",
+	0x8B: "Third-Generation Opteron",
+	0x8C: "Phenom FX",
+	0x8D: "Phenom X4",
+	0x8E: "Phenom X2",
+	0x8F: "Athlon X2",
+	0x90: "PA-RISC",
+	0x91: "PA-RISC 8500",
+	0x92: "PA-RISC 8000",
+	0x93: "PA-RISC 7300LC",
+	0x94: "PA-RISC 7200",
+	0x95: "PA-RISC 7100LC",
+	0x96: "PA-RISC 7100",
+
+	0xA0: "V30",
+	0xA1: "Quad-Core Xeon 3200",
+	0xA2: "Dual-Core Xeon 3000",
+	0xA3: "Quad-Core Xeon 5300",
+	0xA4: "Dual-Core Xeon 5100",
+	0xA5: "Dual-Core Xeon 5000",
+	0xA6: "Dual-Core Xeon LV",
+	0xA7: "Dual-Core Xeon ULV",
+	0xA8: "Dual-Core Xeon 7100",
+	0xA9: "Quad-Core Xeon 5400",
+	0xAA: "Quad-Core Xeon",
+	0xAB: "Dual-Core Xeon 5200",
+	0xAC: "Dual-Core Xeon 7200",
+	0xAD: "Quad-Core Xeon 7300",
+	0xAE: "Quad-Core Xeon 7400",
+	0xAF: "Multi-Core Xeon 7400",
+	0xB0: "Pentium III Xeon",
+	0xB1: "Pentium III Speedstep",
+	0xB2: "Pentium 4",
+	0xB3: "Xeon",
+	0xB4: "AS400",
+	0xB5: "Xeon MP",
+	0xB6: "Athlon XP",
+	0xB7: "Athlon MP",
+	0xB8: "Itanium 2",
+	0xB9: "Pentium M",
+	0xBA: "Celeron D",
+	0xBB: "Pentium D",
+	0xBC: "Pentium EE",
+	0xBD: "Core Solo",
+	0xBF: "Core 2 Duo",
+	0xC0: "Core 2 Solo",
+	0xC1: "Core 2 Extreme",
+	0xC2: "Core 2 Quad",
+	0xC3: "Core 2 Extreme Mobile",
+	0xC4: "Core 2 Duo Mobile",
+	0xC5: "Core 2 Solo Mobile",
+	0xC6: "Core i7",
+	0xC7: "Dual-Core Celeron",
+	0xC8: "IBM390",
+	0xC9: "G4",
+	0xCA: "G5",
+	0xCB: "ESA/390 G6",
+	0xCC: "z/Architecture",
+	0xCD: "Core i5",
+	0xCE: "Core i3",
+	0xCF: "Core i9",
+
+	0xD2: "C7-M",
+	0xD3: "C7-D",
+	0xD4: "C7",
+	0xD5: "Eden",
+	0xD6: "Multi-Core Xeon",
+	0xD7: "Dual-Core Xeon 3xxx",
+	0xD8: "Quad-Core Xeon 3xxx",
+	0xD9: "Nano",
+	0xDA: "Dual-Core Xeon 5xxx",
+	0xDB: "Quad-Core Xeon 5xxx",
+
+	0xDD: "Dual-Core Xeon 7xxx",
+	0xDE: "Quad-Core Xeon 7xxx",
+	0xDF: "Multi-Core Xeon 7xxx",
+	0xE0: "Multi-Core Xeon 3400",
+
+	0xE4: "Opteron 3000",
+	0xE5: "Sempron II",
+	0xE6: "Embedded Opteron Quad-Core",
+	0xE7: "Phenom Triple-Core",
+	0xE8: "Turion Ultra Dual-Core Mobile",
+	0xE9: "Turion Dual-Core Mobile",
+	0xEA: "Athlon Dual-Core",
+	0xEB: "Sempron SI",
+	0xEC: "Phenom II",
+	0xED: "Athlon II",
+	0xEE: "Six-Core Opteron",
+	0xEF: "Sempron M",
+
+	0xFA: "i860",
+	0xFB: "i960",
+
+	0x100: "ARMv7",
+	0x101: "ARMv8",
+	0x102: "ARMv9",
+	0x104: "SH-3",
+	0x105: "SH-4",
+	0x118: "ARM",
+	0x119: "StrongARM",
+	0x12C: "6x86",
+	0x12D: "MediaGX",
+	0x12E: "MII",
+	0x140: "WinChip",
+	0x15E: "DSP",
+	0x1F4: "Video Processor",
+
+	0x200: "RV32",
+	0x201: "RV64",
+	0x202: "RV128",
+
+	0x258: "LoongArch",
+	0x259: "Loongson 1",
+	0x25A: "Loongson 2",
+	0x25B: "Loongson 3",
+	0x25C: "Loongson 2K",
+	0x25D: "Loongson 3A",
+	0x25E: "Loongson 3B",
+	0x25F: "Loongson 3C",
+	0x260: "Loongson 3D",
+	0x261: "Loongson 3E",
+	0x262: "Dual-Core Loongson 2K 2xxx",
+	0x26C: "Quad-Core Loongson 3A 5xxx",
+	0x26D: "Multi-Core Loongson 3A 5xxx",
+	0x26E: "Quad-Core Loongson 3B 5xxx",
+	0x26F: "Multi-Core Loongson 3B 5xxx",
+	0x270: "Multi-Core Loongson 3C 5xxx",
+	0x271: "Multi-Core Loongson 3D 5xxx",
+}
+
+var processorUpgrades = []string{
+	"",
+	"Other",
+	"Unknown",
+	"Daughter Board",
+	"ZIF Socket",
+	"Replaceable Piggy Back",
+	"None",
+	"LIF Socket",
+	"Slot 1",
+	"Slot 2",
+	"370-pin Socket",
+	"Slot A",
+	"Slot M",
+	"Socket 423",
+	"Socket A (Socket 462)",
+	"Socket 478",
+	"Socket 754",
+	"Socket 940",
+	"Socket 939",
+	"Socket mPGA604",
+	"Socket LGA771",
+	"Socket LGA775",
+	"Socket S1",
+	"Socket AM2",
+	"Socket F (1207)",
+	"Socket LGA1366",
+	"Socket G34",
+	"Socket AM3",
+	"Socket C32",
+	"Socket LGA1156",
+	"Socket LGA1567",
+	"Socket PGA988A",
+	"Socket BGA1288",
+	"Socket rPGA988B",
+	"Socket BGA1023",
+	"Socket BGA1224",
+	"Socket BGA1155",
+	"Socket LGA1356",
+	"Socket LGA2011",
+	"Socket FS1",
+	"Socket FS2",
+	"Socket FM1",
+	"Socket FM2",
+	"Socket LGA2011-3",
+	"Socket LGA1356-3",
+	"Socket LGA1150",
+	"Socket BGA1168",
+	"Socket BGA1234",
+	"Socket BGA1364",
+	"Socket AM4",
+	"Socket LGA1151",
+	"Socket BGA1356",
+	"Socket BGA1440",
+	"Socket BGA1515",
+	"Socket LGA3647-1",
+	"Socket SP3",
+	"Socket SP3r2",
+	"Socket LGA2066",
+	"Socket BGA1392",
+	"Socket BGA1510",
+	"Socket BGA1528",
+	"Socket LGA4189",
+	"Socket LGA1200",
+	"Socket LGA4677",
+	"Socket LGA1700",
+	"Socket BGA1744",
+	"Socket BGA1781",
+	"Socket BGA1211",
+	"Socket BGA2422",
+	"Socket LGA1211",
+	"Socket LGA2422",
+	"Socket LGA5773",
+	"Socket BGA5773",
+	"Socket AM5",
+	"Socket SP5",
+	"Socket SP6",
+	"Socket BGA883",
+	"Socket BGA1190",
+	"Socket BGA4129",
+	"Socket LGA4710",
+	"Socket LGA7529",
+}
+
+// processorCharacteristics names bits 2-9 of the characteristics word.
+var processorCharacteristics = []string{
+	"64-bit capable",
+	"Multi-Core",
+	"Hardware Thread",
+	"Execute Protection",
+	"Enhanced Virtualization",
+	"Power/Performance Control",
+	"128-bit Capable",
+	"Arm64 SoC ID",
+}
+
+var cacheModes = []string{
+	"Write Through",
+	"Write Back",
+	"Varies With Memory Address",
+	"Unknown",
+}
+
+var cacheLocations = []string{
+	"Internal",
+	"",
+	"External",
+	"Unknown",
+}
+
+var sramTypes = []string{
+	"Other",
+	"Unknown",
+	"Non-burst",
+	"Burst",
+	"Pipeline Burst",
+	"Synchronous",
+	"Asynchronous",
+}
+
+var cacheErrorCorrectionTypes = []string{
+	"",
+	"Other",
+	"Unknown",
+	"None",
+	"Parity",
+	"Single-bit ECC",
+	"Multi-bit ECC",
+}
+
+var cacheSystemTypes = []string{
+	"",
+	"Other",
+	"Unknown",
+	"Instruction",
+	"Data",
+	"Unified",
+}
+
+var cacheAssociativities = []string{
+	"",
+	"Other",
+	"Unknown",
+	"Direct Mapped",
+	"2-way Set-associative",
+	"4-way Set-associative",
+	"Fully Associative",
+	"8-way Set-associative",
+	"16-way Set-associative",
+	"12-way Set-associative",
+	"24-way Set-associative",
+	"32-way Set-associative",
+	"48-way Set-associative",
+	"64-way Set-associative",
+	"20-way Set-associative",
+}
+
+var portConnectorTypes = []string{
+	"None",
+	"Centronics",
+	"Mini Centronics",
+	"Proprietary",
+	"DB-25 male",
+	"DB-25 female",
+	"DB-15 male",
+	"DB-15 female",
+	"DB-9 male",
+	"DB-9 female",
+	"RJ-11",
+	"RJ-45",
+	"50 Pin MiniSCSI",
+	"Mini DIN",
+	"Micro DIN",
+	"PS/2",
+	"Infrared",
+	"HP-HIL",
+	"Access Bus (USB)",
+	"SSA SCSI",
+	"Circular DIN-8 male",
+	"Circular DIN-8 female",
+	"On Board IDE",
+	"On Board Floppy",
+	"9 Pin Dual Inline (pin 10 cut)",
+	"25 Pin Dual Inline (pin 26 cut)",
+	"50 Pin Dual Inline",
+	"68 Pin Dual Inline",
+	"On Board Sound Input From CD-ROM",
+	"Mini Centronics Type-14",
+	"Mini Centronics Type-26",
+	"Mini Jack (headphones)",
+	"BNC",
+	"IEEE 1394",
+	"SAS/SATA Plug Receptacle",
+	"USB Type-C Receptacle",
+}
+
+// portConnectorTypesA0 continues portConnectorTypes from 0xA0.
+var portConnectorTypesA0 = []string{
+	"PC-98",
+	"PC-98 Hireso",
+	"PC-H98",
+	"PC-98 Note",
+	"PC-98 Full",
+}
+
+var portTypes = []string{
+	"None",
+	"Parallel Port XT/AT Compatible",
+	"Parallel Port PS/2",
+	"Parallel Port ECP",
+	"Parallel Port EPP",
+	"Parallel Port ECP/EPP",
+	"Serial Port XT/AT Compatible",
+	"Serial Port 16450 Compatible",
+	"Serial Port 16550 Compatible",
+	"Serial Port 16550A Compatible",
+	"SCSI Port",
+	"MIDI Port",
+	"Joystick Port",
+	"Keyboard Port",
+	"Mouse Port",
+	"SSA SCSI",
+	"USB",
+	"Firewire (IEEE P1394)",
+	"PCMCIA Type I",
+	"PCMCIA Type II",
+	"PCMCIA Type III",
+	"Cardbus",
+	"Access Bus Port",
+	"SCSI II",
+	"SCSI Wide",
+	"PC-98",
+	"PC-98 Hireso",
+	"PC-H98",
+	"Video Port",
+	"Audio Port",
+	"Modem Port",
+	"Network Port",
+	"SATA",
+	"SAS",
+	"MFDP (Multi-Function Display Port)",
+	"Thunderbolt",
+}
+
+// portTypesA0 continues portTypes from 0xA0.
+var portTypesA0 = []string{
+	"8251 Compatible",
+	"8251 FIFO Compatible",
+}
+
+var slotTypes = []string{
+	"",
+	"Other",
+	"Unknown",
+	"ISA",
+	"MCA",
+	"EISA",
+	"PCI",
+	"PC Card (PCMCIA)",
+	"VLB",
+	"Proprietary",
+	"Processor Card",
+	"Proprietary Memory Card",
+	"I/O Riser Card",
+	"NuBus",
+	"PCI-66",
+	"AGP",
+	"AGP 2x",
+	"AGP 4x",
+	"PCI-X",
+	"AGP 8x",
+	"M.2 Socket 1-DP",
+	"M.2 Socket 1-SD",
+	"M.2 Socket 2",
+	"M.2 Socket 3",
+	"MXM Type I",
+	"MXM Type II",
+	"MXM Type III",
+	"MXM Type III-HE",
+	"MXM Type IV",
+	"MXM 3.0 Type A",
+	"MXM 3.0 Type B",
+	"PCI Express 2 SFF-8639 (U.2)",
+	"PCI Express 3 SFF-8639 (U.2)",
+	"PCI Express Mini 52-pin with bottom-side keep-outs",
+	"PCI Express Mini 52-pin without bottom-side keep-outs",
+	"PCI Express Mini 76-pin",
+	"PCI Express 4 SFF-8639 (U.2)",
+	"PCI Express 5 SFF-8639 (U.2)",
+	"OCP NIC 3.0 Small Form Factor (SFF)",
+	"OCP NIC 3.0 Large Form Factor (LFF)",
+	"OCP NIC Prior to 3.0",
+}
+
+// slotTypesA0 continues slotTypes from 0xA0.
+var slotTypesA0 = []string{
+	"PC-98/C20",
+	"PC-98/C24",
+	"PC-98/E",
+	"PC-98/Local Bus",
+	"PC-98/Card",
+	"PCI Express",
+	"PCI Express x1",
+	"PCI Express x2",
+	"PCI Express x4",
+	"PCI Express x8",
+	"PCI Express x16",
+	"PCI Express 2",
+	"PCI Express 2 x1",
+	"PCI Express 2 x2",
+	"PCI Express 2 x4",
+	"PCI Express 2 x8",
+	"PCI Express 2 x16",
+	"PCI Express 3",
+	"PCI Express 3 x1",
+	"PCI Express 3 x2",
+	"PCI Express 3 x4",
+	"PCI Express 3 x8",
+	"PCI Express 3 x16",
+	"",
+	"PCI Express 4",
+	"PCI Express 4 x1",
+	"PCI Express 4 x2",
+	"PCI Express 4 x4",
+	"PCI Express 4 x8",
+	"PCI Express 4 x16",
+	"PCI Express 5",
+	"PCI Express 5 x1",
+	"PCI Express 5 x2",
+	"PCI Express 5 x4",
+	"PCI Express 5 x8",
+	"PCI Express 5 x16",
+	"PCI Express 6+",
+	"EDSFF E1",
+	"EDSFF E3",
+}
+
+// slotBusWidths are prefixed to the slot type, so each ends in a space.
+var slotBusWidths = []string{
+	"",
+	"",
+	"",
+	"8-bit ",
+	"16-bit ",
+	"32-bit ",
+	"64-bit ",
+	"128-bit ",
+	"x1 ",
+	"x2 ",
+	"x4 ",
+	"x8 ",
+	"x12 ",
+	"x16 ",
+	"x32 ",
+}
+
+var slotUsages = []string{
+	"",
+	"Other",
+	"Unknown",
+	"Available",
+	"In Use",
+	"Unavailable",
+}
+
+var slotLengths = []string{
+	"",
+	"Other",
+	"Unknown",
+	"Short",
+	"Long",
+	"2.5\" drive form factor",
+	"3.5\" drive form factor",
+}
+
+// slotCharacteristics1 names bits 1-7 of the first characteristics byte.
+var slotCharacteristics1 = []string{
+	"5.0 V is provided",
+	"3.3 V is provided",
+	"Opening is shared",
+	"PC Card-16 is supported",
+	"Cardbus is supported",
+	"Zoom Video is supported",
+	"Modem ring resume is supported",
+}
+
+var slotCharacteristics2 = []string{
+	"PME signal is supported",
+	"Hot-plug devices are supported",
+	"SMBus signal is supported",
+	"PCIe slot bifurcation is supported",
+	"Async/surprise removal is supported",
+	"Flexbus slot, CXL 1.0 capable",
+	"Flexbus slot, CXL 2.0 capable",
+	"Flexbus slot, CXL 3.0 capable",
+}
+
+var slotHeights = []string{
+	"Not applicable",
+	"Other",
+	"Unknown",
+	"Full height",
+	"Low-profile",
+}
+
+var onBoardDeviceTypes = []string{
+	"",
+	"Other",
+	"Unknown",
+	"Video",
+	"SCSI Controller",
+	"Ethernet",
+	"Token Ring",
+	"Sound",
+	"PATA Controller",
+	"SATA Controller",
+	"SAS Controller",
+}
+
+var memoryArrayLocations = []string{
+	"",
+	"Other",
+	"Unknown",
+	"System Board Or Motherboard",
+	"ISA Add-on Card",
+	"EISA Add-on Card",
+	"PCI Add-on Card",
+	"MCA Add-on Card",
+	"PCMCIA Add-on Card",
+	"Proprietary Add-on Card",
+	"NuBus",
+}
+
+// memoryArrayLocationsA0 continues memoryArrayLocations from 0xA0.
+var memoryArrayLocationsA0 = []string{
+	"PC-98/C20 Add-on Card",
+	"PC-98/C24 Add-on Card",
+	"PC-98/E Add-on Card",
+	"PC-98/Local Bus Add-on Card",
+	"CXL Add-on Card",
+}
+
+var memoryArrayUses = []string{
+	"",
+	"Other",
+	"Unknown",
+	"System Memory",
+	"Video Memory",
+	"Flash Memory",
+	"Non-volatile RAM",
+	"Cache Memory",
+}
+
+var memoryArrayErrorCorrectionTypes = []string{
+	"",
+	"Other",
+	"Unknown",
+	"None",
+	"Parity",
+	"Single-bit ECC",
+	"Multi-bit ECC",
+	"CRC",
+}
+
+var memoryTypeDetails = []string{
+	"",
+	"Other",
+	"Unknown",
+	"Fast-paged",
+	"Static Column",
+	"Pseudo-static",
+	"RAMBus",
+	"Synchronous",
+	"CMOS",
+	"EDO",
+	"Window DRAM",
+	"Cache DRAM",
+	"Non-Volatile",
+	"Registered (Buffered)",
+	"Unbuffered (Unregistered)",
+	"LRDIMM",
+}
+
+var memoryTechnologies = []string{
+	"",
+	"Other",
+	"Unknown",
+	"DRAM",
+	"NVDIMM-N",
+	"NVDIMM-F",
+	"NVDIMM-P",
+	"Intel Optane DC persistent memory",
+}
+
+// memoryOperatingModes names bits 1-5 of the operating mode capability word.
+var memoryOperatingModes = []string{
+	"",
+	"Other",
+	"Unknown",
+	"Volatile memory",
+	"Byte-accessible persistent memory",
+	"Block-accessible persistent memory",
+}
+
+var memoryErrorTypes = []string{
+	"",
+	"Other",
+	"Unknown",
+	"OK",
+	"Bad Read",
+	"Parity Error",
+	"Single-bit Error",
+	"Double-bit Error",
+	"Multi-bit Error",
+	"Nibble Error",
+	"Checksum Error",
+	"CRC Error",
+	"Corrected Single-bit Error",
+	"Corrected Error",
+	"Uncorrectable Error",
+}
+
+var memoryErrorGranularities = []string{
+	"",
+	"Other",
+	"Unknown",
+	"Device Level",
+	"Memory Partition Level",
+}
+
+var memoryErrorOperations = []string{
+	"",
+	"Other",
+	"Unknown",
+	"Read",
+	"Write",
+	"Partial Write",
+}
+
+var hardwareSecurityStatuses = []string{
+	"Disabled",
+	"Enabled",
+	"Not Implemented",
+	"Unknown",
+}
+
+var bootStatuses = []string{
+	"No errors detected",
+	"No bootable media",
+	"Operating system failed to load",
+	"Firmware-detected hardware failure",
+	"Operating system-detected hardware failure",
+	"User-requested boot",
+	"System security violation",
+	"Previously-requested image",
+	"System watchdog timer expired",
+}
+
+// tpmCharacteristics names bits 2-5 of the TPM characteristics qword.
+var tpmCharacteristics = []string{
+	"TPM Device characteristics not supported",
+	"Family configurable via firmware update",
+	"Family configurable via platform software support",
+	"Family configurable via OEM proprietary mechanism",
+}
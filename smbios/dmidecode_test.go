@@ -0,0 +1,70 @@
+package smbios
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestRenderDmidecode compares the rendering of each fixture with its golden file,
+// which is laid out as dmidecode 3.5 prints the same structures from sysfs, less the
+// "Table at" line.
+func TestRenderDmidecode(t *testing.T) {
+	for _, tt := range []struct{ fixture, golden string }{
+		{"rsmb_3_2.bin", "dmidecode_3_2.txt"},
+		{"rsmb_2_4.bin", "dmidecode_2_4.txt"},
+	} {
+		got := strings.Split(RenderDmidecode(parseFixture(t, tt.fixture), DmidecodeSourceLine(SysfsSource{})), "\n")
+		want := strings.Split(string(readFixture(t, tt.golden)), "\n")
+		for i := range min(len(got), len(want)) {
+			if got[i] != want[i] {
+				t.Fatalf("%s line %d = %q, want %q", tt.golden, i+1, got[i], want[i])
+			}
+		}
+		if len(got) != len(want) {
+			t.Errorf("%s: got %d lines, want %d", tt.golden, len(got), len(want))
+		}
+	}
+}
+
+func TestDmidecodeSourceLine(t *testing.T) {
+	tests := []struct {
+		src  Source
+		want string
+	}{
+		{SysfsSource{}, "Getting SMBIOS data from sysfs."},
+		{SysfsSource{Dir: "/tmp/dmi"}, "Getting SMBIOS data from sysfs."},
+		{FileSource{Path: "rsmb.bin"}, "Reading SMBIOS/DMI data from file rsmb.bin."},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		if got := DmidecodeSourceLine(tt.src); got != tt.want {
+			t.Errorf("DmidecodeSourceLine(%#v) = %q, want %q", tt.src, got, tt.want)
+		}
+	}
+
+	// Without a source line the header goes straight to the version
+	lines := strings.SplitN(RenderDmidecode(parseFixture(t, "rsmb_3_2.bin"), ""), "\n", 3)
+	if lines[1] != "SMBIOS 3.2.0 present." {
+		t.Errorf("second line without a source = %q, want the SMBIOS version", lines[1])
+	}
+}
+
+func TestDmiMemorySize(t *testing.T) {
+	tests := []struct {
+		code  uint64
+		shift int
+		want  string
+	}{
+		{512, 1, "512 kB"},
+		{2048, 1, "2 MB"},
+		{1536, 1, "1536 kB"},
+		{16 << 30, 0, "16 GB"},
+		{0x04000000, 1, "64 GB"},
+		{3 << 40, 0, "3 TB"},
+	}
+	for _, tt := range tests {
+		if got := dmiMemorySize(tt.code, tt.shift); got != tt.want {
+			t.Errorf("dmiMemorySize(%d, %d) = %q, want %q", tt.code, tt.shift, got, tt.want)
+		}
+	}
+}
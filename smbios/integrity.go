@@ -0,0 +1,175 @@
+package smbios
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Anomaly is one structural inconsistency found in a RawSMBIOSData buffer.
+type Anomaly struct {
+	// Offset is relative to the start of the RawSMBIOSData buffer, so it
+	// points at the same byte in a -dump file.
+	Offset  int
+	Message string
+}
+
+func (a Anomaly) String() string {
+	return fmt.Sprintf("0x%04X: %s", a.Offset, a.Message)
+}
+
+// minimumLengths lists, per structure type, the minimum formatted-area length
+// required from each SMBIOS version on. Entries are in ascending version order.
+var minimumLengths = map[uint8][]struct {
+	major, minor byte
+	length       int
+}{
+	TypeBIOSInformation:      {{2, 0, 0x12}, {2, 4, 0x18}, {3, 1, 0x1A}},
+	TypeSystemInformation:    {{2, 0, 0x08}, {2, 1, 0x19}, {2, 4, 0x1B}},
+	TypeBaseboard:            {{2, 0, 0x08}},
+	TypeChassis:              {{2, 0, 0x09}, {2, 1, 0x0D}, {2, 3, 0x15}},
+	TypeProcessorInformation: {{2, 0, 0x1A}, {2, 3, 0x23}, {2, 5, 0x28}, {2, 6, 0x2A}, {3, 0, 0x30}},
+	TypeOEMStrings:           {{2, 0, 0x05}},
+	TypeConfigurationOptions: {{2, 0, 0x05}},
+	TypeMemoryDevice:         {{2, 1, 0x15}, {2, 3, 0x1B}, {2, 6, 0x1C}, {2, 7, 0x22}, {2, 8, 0x28}, {3, 2, 0x54}, {3, 3, 0x5C}},
+	TypeEndOfTable:           {{2, 0, 0x04}},
+}
+
+// stringFieldOffsets lists the string-index fields of the decoded types.
+var stringFieldOffsets = map[uint8][]int{
+	TypeBIOSInformation:      {0x04, 0x05, 0x08},
+	TypeSystemInformation:    {0x04, 0x05, 0x06, 0x07, 0x19, 0x1A},
+	TypeBaseboard:            {0x04, 0x05, 0x06, 0x07, 0x08, 0x0A},
+	TypeChassis:              {0x04, 0x06, 0x07, 0x08},
+	TypeProcessorInformation: {0x04, 0x07, 0x10, 0x20, 0x21, 0x22},
+	TypeMemoryDevice:         {0x10, 0x11, 0x17, 0x18, 0x19, 0x1A},
+}
+
+// minimumLength returns the smallest formatted area a structure of s's type
+// may have in a table of the given version, or 0 when unknown.
+func minimumLength(s *Structure, major, minor byte) int {
+	required := 0
+	for _, m := range minimumLengths[s.Type] {
+		if major > m.major || (major == m.major && minor >= m.minor) {
+			required = m.length
+		}
+	}
+	// From 2.7 the chassis SKU byte follows the contained element records
+	if s.Type == TypeChassis && (major > 2 || (major == 2 && minor >= 7)) && len(s.Formatted) >= 0x15 {
+		required = 0x15 + int(s.Formatted[0x13])*int(s.Formatted[0x14]) + 1
+	}
+	return required
+}
+
+// CheckIntegrity walks a RawSMBIOSData buffer and reports structural
+// anomalies a spoofer rewriting the table may leave behind: header length
+// mismatches, malformed or undersized structures, string indexes past the
+// string set, duplicate handles and a missing end-of-table marker.
+func CheckIntegrity(raw []byte) []Anomaly {
+	var anomalies []Anomaly
+	add := func(offset int, format string, args ...any) {
+		anomalies = append(anomalies, Anomaly{Offset: offset, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(raw) < RawHeaderSize {
+		add(0, "buffer is %d bytes, shorter than the RawSMBIOSData header", len(raw))
+		return anomalies
+	}
+	major, minor := raw[1], raw[2]
+	length := int(binary.LittleEndian.Uint32(raw[4:8]))
+	data := raw[RawHeaderSize:]
+	switch {
+	case length > len(data):
+		add(4, "RawSMBIOSData.Length is %d but only %d table bytes were returned", length, len(data))
+	case length < len(data):
+		add(RawHeaderSize+length, "%d bytes returned beyond RawSMBIOSData.Length %d", len(data)-length, length)
+		data = data[:length]
+	}
+
+	handles := make(map[uint16]int)
+	sawEnd := false
+	offset := 0
+	for offset < len(data) {
+		abs := RawHeaderSize + offset
+		if sawEnd {
+			for _, b := range data[offset:] {
+				if b != 0 {
+					add(abs, "%d bytes of data after the end-of-table marker", len(data)-offset)
+					break
+				}
+			}
+			break
+		}
+		if offset+HeaderSize > len(data) {
+			add(abs, "%d trailing bytes are too short for a structure header", len(data)-offset)
+			break
+		}
+
+		typ, structLength := data[offset], int(data[offset+1])
+		if structLength < HeaderSize {
+			add(abs, "type %d structure declares length %d, below the 4-byte header", typ, structLength)
+			break
+		}
+		if offset+structLength > len(data) {
+			add(abs, "type %d structure of %d bytes runs past the end of the table", typ, structLength)
+			break
+		}
+		strings, next, err := parseStrings(data, offset+structLength)
+		if err != nil {
+			add(RawHeaderSize+offset+structLength, "type %d structure has an unterminated string set", typ)
+			break
+		}
+		s := Structure{
+			Type:      typ,
+			Length:    uint8(structLength),
+			Handle:    binary.LittleEndian.Uint16(data[offset+2 : offset+4]),
+			Offset:    offset,
+			Formatted: data[offset : offset+structLength],
+			Strings:   strings,
+		}
+
+		if first, dup := handles[s.Handle]; dup {
+			add(abs, "duplicate handle 0x%04X (first used by the structure at 0x%04X)", s.Handle, first)
+		} else {
+			handles[s.Handle] = abs
+		}
+		if required := minimumLength(&s, major, minor); structLength < required {
+			add(abs, "type %d structure is %d bytes, below the SMBIOS %d.%d minimum of %d", typ, structLength, major, minor, required)
+		}
+		for _, field := range stringFields(&s) {
+			if index := int(s.Formatted[field]); index > len(s.Strings) {
+				add(abs+field, "type %d string index %d at field 0x%02X points past the %d-string set", typ, index, field, len(s.Strings))
+			}
+		}
+		if (typ == TypeOEMStrings || typ == TypeConfigurationOptions) && structLength > 0x04 {
+			if count := int(s.Formatted[0x04]); count != len(s.Strings) {
+				add(abs+0x04, "type %d declares %d strings but its string set holds %d", typ, count, len(s.Strings))
+			}
+		}
+
+		if typ == TypeEndOfTable {
+			sawEnd = true
+		}
+		offset = next
+	}
+
+	if !sawEnd {
+		add(RawHeaderSize+len(data), "missing Type 127 end-of-table marker")
+	}
+	return anomalies
+}
+
+// stringFields returns the string-index field offsets present in s's formatted area.
+func stringFields(s *Structure) []int {
+	var fields []int
+	for _, field := range stringFieldOffsets[s.Type] {
+		if field < len(s.Formatted) {
+			fields = append(fields, field)
+		}
+	}
+	if s.Type == TypeChassis && len(s.Formatted) > 0x15 {
+		if sku := 0x15 + int(s.Formatted[0x13])*int(s.Formatted[0x14]); sku < len(s.Formatted) {
+			fields = append(fields, sku)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,117 @@
+package smbios
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+)
+
+// rawStructure lays out a structure: its formatted area after the 4-byte
+// header, then the string set.
+func rawStructure(typ uint8, handle uint16, fields []byte, strs ...string) []byte {
+	b := []byte{typ, byte(HeaderSize + len(fields)), byte(handle), byte(handle >> 8)}
+	b = append(b, fields...)
+	for _, s := range strs {
+		b = append(append(b, s...), 0)
+	}
+	if len(strs) == 0 {
+		b = append(b, 0)
+	}
+	return append(b, 0)
+}
+
+// rawTable builds a RawSMBIOSData buffer for the given version.
+func rawTable(major, minor byte, structures ...[]byte) []byte {
+	var data []byte
+	for _, s := range structures {
+		data = append(data, s...)
+	}
+	raw := make([]byte, RawHeaderSize, RawHeaderSize+len(data))
+	raw[1], raw[2] = major, minor
+	binary.LittleEndian.PutUint32(raw[4:8], uint32(len(data)))
+	return append(raw, data...)
+}
+
+var endOfTable = rawStructure(TypeEndOfTable, 0xFEFF, nil)
+
+func TestCheckIntegrityFixtures(t *testing.T) {
+	if anomalies := CheckIntegrity(readFixture(t, "rsmb_3_2.bin")); len(anomalies) != 0 {
+		t.Errorf("rsmb_3_2.bin: got anomalies %v, want none", anomalies)
+	}
+
+	tests := []struct {
+		fixture string
+		want    []Anomaly
+	}{
+		{"missing_terminator.bin", []Anomaly{
+			{0x8E, "type 11 structure has an unterminated string set"},
+			{0xA2, "missing Type 127 end-of-table marker"},
+		}},
+		{"truncated_structure.bin", []Anomaly{
+			{0xF8, "type 4 structure of 48 bytes runs past the end of the table"},
+			{0x118, "missing Type 127 end-of-table marker"},
+		}},
+	}
+	for _, tt := range tests {
+		got := CheckIntegrity(readFixture(t, tt.fixture))
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: got anomalies %v, want %v", tt.fixture, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%s: anomaly %d = %v, want %v", tt.fixture, i, got[i], tt.want[i])
+			}
+		}
+	}
+}
+
+func TestCheckIntegrity(t *testing.T) {
+	system := rawStructure(TypeSystemInformation, 0x0001, []byte{1, 2, 0, 0}, "Dell Inc.", "OptiPlex")
+	oem := rawStructure(TypeOEMStrings, 0x000B, []byte{2}, "one", "two")
+	sysOffset := RawHeaderSize
+	afterSystem := RawHeaderSize + len(system)
+
+	// A copy of the OEM structure reusing the system handle
+	duplicate := rawStructure(TypeOEMStrings, 0x0001, []byte{1}, "one")
+	// Product Name points at a third string the set doesn't hold
+	badIndex := rawStructure(TypeSystemInformation, 0x0001, []byte{1, 3, 0, 0}, "Dell Inc.", "OptiPlex")
+	// The 2.4 Type 1 minimum is 0x1B bytes
+	undersized := rawTable(2, 4, system, endOfTable)
+	longer := rawTable(2, 0, system, endOfTable)
+	binary.LittleEndian.PutUint32(longer[4:8], uint32(len(longer)-RawHeaderSize+4))
+	shorter := append(rawTable(2, 0, system, endOfTable), 0, 0, 0)
+	badLength := rawTable(2, 0, system, []byte{TypeOEMStrings, 3, 0, 0})
+
+	tests := []struct {
+		name    string
+		raw     []byte
+		offset  int
+		message string
+	}{
+		{"duplicate handle", rawTable(2, 0, system, duplicate, endOfTable), afterSystem, "duplicate handle 0x0001 (first used by the structure at 0x0008)"},
+		{"string index out of range", rawTable(2, 0, badIndex, endOfTable), sysOffset + 0x05, "string index 3 at field 0x05 points past the 2-string set"},
+		{"OEM string count", rawTable(2, 0, system, rawStructure(TypeOEMStrings, 0x000B, []byte{3}, "one"), endOfTable), afterSystem + 0x04, "declares 3 strings but its string set holds 1"},
+		{"missing Type 127", rawTable(2, 0, system, oem), afterSystem + len(oem), "missing Type 127 end-of-table marker"},
+		{"undersized structure", undersized, sysOffset, "type 1 structure is 8 bytes, below the SMBIOS 2.4 minimum of 27"},
+		{"length below header", badLength, afterSystem, "declares length 3, below the 4-byte header"},
+		{"header length too long", longer, 4, "RawSMBIOSData.Length is"},
+		{"bytes past header length", shorter, len(shorter) - 3, "3 bytes returned beyond RawSMBIOSData.Length"},
+		{"data after Type 127", rawTable(2, 0, system, endOfTable, oem), afterSystem + len(endOfTable), "bytes of data after the end-of-table marker"},
+	}
+	if anomalies := CheckIntegrity(rawTable(2, 0, system, oem, endOfTable)); len(anomalies) != 0 {
+		t.Fatalf("well-formed table: got anomalies %v", anomalies)
+	}
+	for _, tt := range tests {
+		found := false
+		anomalies := CheckIntegrity(tt.raw)
+		for _, a := range anomalies {
+			if a.Offset == tt.offset && strings.Contains(a.Message, tt.message) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%s: got anomalies %v, want %q at 0x%04X", tt.name, anomalies, tt.message, tt.offset)
+		}
+	}
+}
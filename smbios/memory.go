@@ -0,0 +1,170 @@
+package smbios
+
+import (
+	"fmt"
+)
+
+// MemoryDevice is a decoded Type 17 structure.
+type MemoryDevice struct {
+	Handle        uint16
+	ArrayHandle   uint16
+	DeviceLocator string
+	BankLocator   string
+	// Size is in bytes; 0 means no module is installed and SizeUnknown is
+	// set when the firmware reports 0xFFFF.
+	Size         uint64
+	SizeUnknown  bool
+	FormFactor   byte
+	MemoryType   byte
+	Speed        uint32 // MT/s, 0 when unknown
+	Manufacturer string
+	SerialNumber string
+	AssetTag     string
+	PartNumber   string
+	// ConfiguredSpeed is in MT/s, 0 when unknown or not reported.
+	ConfiguredSpeed uint32
+}
+
+var memoryFormFactors = []string{
+	"",
+	"Other",
+	"Unknown",
+	"SIMM",
+	"SIP",
+	"Chip",
+	"DIP",
+	"ZIP",
+	"Proprietary Card",
+	"DIMM",
+	"TSOP",
+	"Row Of Chips",
+	"RIMM",
+	"SODIMM",
+	"SRIMM",
+	"FB-DIMM",
+	"Die",
+	"CAMM",
+}
+
+var memoryTypes = []string{
+	"",
+	"Other",
+	"Unknown",
+	"DRAM",
+	"EDRAM",
+	"VRAM",
+	"SRAM",
+	"RAM",
+	"ROM",
+	"Flash",
+	"EEPROM",
+	"FEPROM",
+	"EPROM",
+	"CDRAM",
+	"3DRAM",
+	"SDRAM",
+	"SGRAM",
+	"RDRAM",
+	"DDR",
+	"DDR2",
+	"DDR2 FB-DIMM",
+	"Reserved",
+	"Reserved",
+	"Reserved",
+	"DDR3",
+	"FBD2",
+	"DDR4",
+	"LPDDR",
+	"LPDDR2",
+	"LPDDR3",
+	"LPDDR4",
+	"Logical non-volatile device",
+	"HBM",
+	"HBM2",
+	"DDR5",
+	"LPDDR5",
+	"HBM3",
+}
+
+// DecodeMemoryDevice decodes a Type 17 structure.
+func DecodeMemoryDevice(s *Structure) (MemoryDevice, error) {
+	if s.Type != TypeMemoryDevice {
+		return MemoryDevice{}, fmt.Errorf("structure 0x%04X is type %d, not a memory device", s.Handle, s.Type)
+	}
+	if s.Length < 0x15 {
+		return MemoryDevice{}, fmt.Errorf("memory device structure (Type 17) too short (%d bytes)", s.Length)
+	}
+
+	m := MemoryDevice{
+		Handle:        s.Handle,
+		DeviceLocator: s.stringField(0x10),
+		BankLocator:   s.stringField(0x11),
+		Manufacturer:  s.stringField(0x17),
+		SerialNumber:  s.stringField(0x18),
+		AssetTag:      s.stringField(0x19),
+		PartNumber:    s.stringField(0x1A),
+	}
+	m.ArrayHandle, _ = s.Word(0x04)
+	m.FormFactor, _ = s.Byte(0x0E)
+	m.MemoryType, _ = s.Byte(0x12)
+
+	size, _ := s.Word(0x0C)
+	switch {
+	case size == 0xFFFF:
+		m.SizeUnknown = true
+	case size == 0x7FFF:
+		// The real size (in MB) lives in the 2.7 Extended Size dword
+		if ext, ok := s.DWord(0x1C); ok {
+			m.Size = uint64(ext&0x7FFFFFFF) << 20
+		}
+	case size&0x8000 != 0:
+		m.Size = uint64(size&0x7FFF) << 10
+	default:
+		m.Size = uint64(size) << 20
+	}
+
+	m.Speed = speedField(s, 0x15, 0x54)
+	m.ConfiguredSpeed = speedField(s, 0x20, 0x58)
+	return m, nil
+}
+
+// speedField reads a speed word, falling back to the 3.3 extended dword when
+// the word is 0xFFFF.
+func speedField(s *Structure, offset, extOffset int) uint32 {
+	speed, _ := s.Word(offset)
+	if speed == 0xFFFF {
+		ext, _ := s.DWord(extOffset)
+		return ext & 0x7FFFFFFF
+	}
+	return uint32(speed)
+}
+
+// Installed reports whether a module is present in the socket.
+func (m MemoryDevice) Installed() bool {
+	return m.Size != 0 || m.SizeUnknown
+}
+
+// SizeString formats Size the way dmidecode does.
+func (m MemoryDevice) SizeString() string {
+	switch {
+	case m.SizeUnknown:
+		return "Unknown"
+	case m.Size == 0:
+		return "No Module Installed"
+	case m.Size%(1<<30) == 0:
+		return fmt.Sprintf("%d GB", m.Size>>30)
+	case m.Size%(1<<20) == 0:
+		return fmt.Sprintf("%d MB", m.Size>>20)
+	}
+	return fmt.Sprintf("%d kB", m.Size>>10)
+}
+
+// FormFactorString names the form factor, e.g. "SODIMM".
+func (m MemoryDevice) FormFactorString() string {
+	return enumString(memoryFormFactors, m.FormFactor)
+}
+
+// MemoryTypeString names the memory type, e.g. "DDR4".
+func (m MemoryDevice) MemoryTypeString() string {
+	return enumString(memoryTypes, m.MemoryType)
+}
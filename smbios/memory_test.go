@@ -0,0 +1,97 @@
+package smbios
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func TestMemoryDeviceFixture(t *testing.T) {
+	table := parseFixture(t, "rsmb_3_2.bin")
+	devices := table.All(TypeMemoryDevice)
+	if len(devices) != 2 {
+		t.Fatalf("got %d memory devices, want 2", len(devices))
+	}
+	m, err := DecodeMemoryDevice(devices[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.DeviceLocator != "DIMM1" || m.SizeString() != "16 GB" || m.MemoryTypeString() != "DDR4" || m.FormFactorString() != "DIMM" {
+		t.Errorf("DIMM1 = %q %s %s %s, want DIMM1 16 GB DDR4 DIMM", m.DeviceLocator, m.SizeString(), m.MemoryTypeString(), m.FormFactorString())
+	}
+	if m.Speed != 2933 || m.ConfiguredSpeed != 2933 || m.PartNumber != "HMA82GU6CJR8N-WM" {
+		t.Errorf("DIMM1 speed %d/%d, part number %q; want 2933/2933, HMA82GU6CJR8N-WM", m.Speed, m.ConfiguredSpeed, m.PartNumber)
+	}
+
+	empty, err := DecodeMemoryDevice(devices[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if empty.Installed() || empty.SizeString() != "No Module Installed" {
+		t.Errorf("DIMM2 installed = %v, size %q; want an empty slot", empty.Installed(), empty.SizeString())
+	}
+}
+
+// memoryDevice builds a Type 17 structure of the given length with the size
+// and speed words set and the extended fields filled in when they fit.
+func memoryDevice(length int, size, speed uint16, extSize, extSpeed uint32) *Structure {
+	fields := make([]byte, length-HeaderSize)
+	put16 := func(offset int, v uint16) {
+		if offset+2 <= length {
+			binary.LittleEndian.PutUint16(fields[offset-HeaderSize:], v)
+		}
+	}
+	put32 := func(offset int, v uint32) {
+		if offset+4 <= length {
+			binary.LittleEndian.PutUint32(fields[offset-HeaderSize:], v)
+		}
+	}
+	put16(0x0C, size)
+	put16(0x15, speed)
+	put16(0x20, speed)
+	put32(0x1C, extSize)
+	put32(0x54, extSpeed)
+	put32(0x58, extSpeed)
+	return structure(TypeMemoryDevice, fields...)
+}
+
+func TestDecodeMemoryDevice(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     *Structure
+		size  string
+		speed uint32
+	}{
+		{"MB granularity", memoryDevice(0x28, 8192, 3200, 0, 0), "8 GB", 3200},
+		{"kB granularity", memoryDevice(0x28, 0x8000|512, 66, 0, 0), "512 kB", 66},
+		{"extended size", memoryDevice(0x28, 0x7FFF, 3200, 64<<10, 0), "64 GB", 3200},
+		// The top bit of Extended Size is reserved
+		{"extended size, reserved bit", memoryDevice(0x28, 0x7FFF, 3200, 0x80000000|48<<10, 0), "48 GB", 3200},
+		{"unknown size", memoryDevice(0x28, 0xFFFF, 0, 0, 0), "Unknown", 0},
+		{"extended speed", memoryDevice(0x5C, 16384, 0xFFFF, 0, 70000), "16 GB", 70000},
+		{"extended speed, reserved bit", memoryDevice(0x5C, 16384, 0xFFFF, 0, 0x80000000|70000), "16 GB", 70000},
+		// A 3.2 structure ends before Extended Speed
+		{"0xFFFF speed without extended speed", memoryDevice(0x54, 16384, 0xFFFF, 0, 0), "16 GB", 0},
+		// A 2.1 structure ends before the speed word
+		{"2.1 length", memoryDevice(0x15, 1024, 0, 0, 0), "1 GB", 0},
+		{"2.3 length", memoryDevice(0x1B, 2048, 800, 0, 0), "2 GB", 800},
+	}
+	for _, tt := range tests {
+		m, err := DecodeMemoryDevice(tt.s)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got := m.SizeString(); got != tt.size {
+			t.Errorf("%s: size = %q, want %q", tt.name, got, tt.size)
+		}
+		if m.Speed != tt.speed {
+			t.Errorf("%s: speed = %d, want %d", tt.name, m.Speed, tt.speed)
+		}
+		if m.ConfiguredSpeed != 0 && m.ConfiguredSpeed != m.Speed {
+			t.Errorf("%s: configured speed = %d, want %d", tt.name, m.ConfiguredSpeed, m.Speed)
+		}
+	}
+
+	if _, err := DecodeMemoryDevice(structure(TypeMemoryDevice, make([]byte, 0x14-HeaderSize)...)); err == nil {
+		t.Error("structure shorter than 2.1 decoded without error")
+	}
+}
@@ -0,0 +1,35 @@
+package smbios
+
+import (
+	"fmt"
+)
+
+// StringList is a decoded Type 11 (OEM Strings) or Type 12 (System
+// Configuration Options) structure: a counted list of free-form strings.
+type StringList struct {
+	Handle  uint16
+	Type    uint8
+	Strings []string
+}
+
+// DecodeStringList decodes a Type 11 or Type 12 structure. The count byte at
+// offset 0x04 says how many strings the structure owns.
+func DecodeStringList(s *Structure) (StringList, error) {
+	if s.Type != TypeOEMStrings && s.Type != TypeConfigurationOptions {
+		return StringList{}, fmt.Errorf("structure 0x%04X is type %d, not an OEM string or configuration option list", s.Handle, s.Type)
+	}
+	count, ok := s.Byte(0x04)
+	if !ok {
+		return StringList{}, fmt.Errorf("type %d structure too short (%d bytes) to contain a string count", s.Type, s.Length)
+	}
+
+	list := StringList{Handle: s.Handle, Type: s.Type}
+	for i := 1; i <= int(count); i++ {
+		if i > len(s.Strings) {
+			list.Strings = append(list.Strings, BadIndex)
+			continue
+		}
+		list.Strings = append(list.Strings, s.Strings[i-1])
+	}
+	return list, nil
+}
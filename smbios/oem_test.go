@@ -0,0 +1,63 @@
+package smbios
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeStringList(t *testing.T) {
+	tests := []struct {
+		name    string
+		typ     uint8
+		count   byte
+		strings []string
+		want    []string
+	}{
+		{"Type 11", TypeOEMStrings, 2, []string{"Dell System", "1[0A3C]"}, []string{"Dell System", "1[0A3C]"}},
+		{"Type 11, no strings", TypeOEMStrings, 0, nil, nil},
+		{"Type 11, count past the string set", TypeOEMStrings, 3, []string{"Dell System"}, []string{"Dell System", BadIndex, BadIndex}},
+		{"Type 11, count below the string set", TypeOEMStrings, 1, []string{"Dell System", "extra"}, []string{"Dell System"}},
+		{"Type 12", TypeConfigurationOptions, 1, []string{"PASSWORD: Clear password"}, []string{"PASSWORD: Clear password"}},
+		{"Type 12, no strings", TypeConfigurationOptions, 0, nil, nil},
+		{"Type 12, count past the string set", TypeConfigurationOptions, 2, nil, []string{BadIndex, BadIndex}},
+	}
+	for _, tt := range tests {
+		s := structure(tt.typ, tt.count)
+		s.Handle = 0x0B00
+		s.Strings = tt.strings
+		list, err := DecodeStringList(s)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if list.Type != tt.typ || list.Handle != 0x0B00 || !reflect.DeepEqual(list.Strings, tt.want) {
+			t.Errorf("%s: got type %d, handle 0x%04X, strings %q; want type %d, handle 0x0B00, strings %q", tt.name, list.Type, list.Handle, list.Strings, tt.typ, tt.want)
+		}
+	}
+
+	if _, err := DecodeStringList(structure(TypeOEMStrings)); err == nil {
+		t.Error("structure without a count byte decoded without error")
+	}
+	if _, err := DecodeStringList(structure(TypeSystemInformation, 1)); err == nil {
+		t.Error("Type 1 structure decoded as a string list")
+	}
+}
+
+func TestDecodeStringListFixture(t *testing.T) {
+	table := parseFixture(t, "rsmb_3_2.bin")
+	tests := []struct {
+		typ  uint8
+		want []string
+	}{
+		{TypeOEMStrings, []string{"Dell System", "1[0A3C]"}},
+		{TypeConfigurationOptions, []string{"NVRAM_CLR: Clear user settable NVRAM areas and set defaults"}},
+	}
+	for _, tt := range tests {
+		list, err := DecodeStringList(table.First(tt.typ))
+		if err != nil {
+			t.Fatalf("type %d: %v", tt.typ, err)
+		}
+		if !reflect.DeepEqual(list.Strings, tt.want) {
+			t.Errorf("type %d strings = %q, want %q", tt.typ, list.Strings, tt.want)
+		}
+	}
+}
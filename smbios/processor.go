@@ -0,0 +1,246 @@
+package smbios
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ProcessorInformation is a decoded Type 4 structure.
+type ProcessorInformation struct {
+	Handle            uint16
+	SocketDesignation string
+	ProcessorType     byte
+	Family            uint16
+	Manufacturer      string
+	// ProcessorID is the raw 8-byte field: the CPUID leaf 1 EAX signature
+	// followed by the EDX feature flags on x86.
+	ProcessorID  [8]byte
+	Version      string
+	MaxSpeed     uint16 // MHz
+	CurrentSpeed uint16 // MHz
+	Populated    bool
+	SerialNumber string
+	AssetTag     string
+	PartNumber   string
+	CoreCount    uint16
+	CoreEnabled  uint16
+	ThreadCount  uint16
+}
+
+// CPUSignature is the CPUID leaf 1 EAX value broken into its fields.
+type CPUSignature struct {
+	Stepping       byte
+	Model          byte
+	Family         byte
+	Type           byte
+	ExtendedModel  byte
+	ExtendedFamily byte
+}
+
+// cpuFeatures names the CPUID leaf 1 EDX bits; "" marks reserved bits.
+var cpuFeatures = [32]string{
+	"FPU", "VME", "DE", "PSE", "TSC", "MSR", "PAE", "MCE",
+	"CX8", "APIC", "", "SEP", "MTRR", "PGE", "MCA", "CMOV",
+	"PAT", "PSE-36", "PSN", "CLFSH", "", "DS", "ACPI", "MMX",
+	"FXSR", "SSE", "SSE2", "SS", "HTT", "TM", "", "PBE",
+}
+
+// DecodeProcessorInformation decodes a Type 4 structure.
+func DecodeProcessorInformation(s *Structure) (ProcessorInformation, error) {
+	if s.Type != TypeProcessorInformation {
+		return ProcessorInformation{}, fmt.Errorf("structure 0x%04X is type %d, not processor information", s.Handle, s.Type)
+	}
+	if s.Length < 0x10 {
+		return ProcessorInformation{}, fmt.Errorf("processor information structure (Type 4) too short (%d bytes) to contain ProcessorID (requires at least 16)", s.Length)
+	}
+
+	p := ProcessorInformation{
+		Handle:            s.Handle,
+		SocketDesignation: s.stringField(0x04),
+		Manufacturer:      s.stringField(0x07),
+		Version:           s.stringField(0x10),
+		SerialNumber:      s.stringField(0x20),
+		AssetTag:          s.stringField(0x21),
+		PartNumber:        s.stringField(0x22),
+	}
+	p.ProcessorType, _ = s.Byte(0x05)
+	family, _ := s.Byte(0x06)
+	p.Family = uint16(family)
+	if family2, ok := s.Word(0x28); ok && family == 0xFE {
+		p.Family = family2
+	}
+	copy(p.ProcessorID[:], s.Formatted[0x08:0x10])
+	p.MaxSpeed, _ = s.Word(0x14)
+	p.CurrentSpeed, _ = s.Word(0x16)
+	status, _ := s.Byte(0x18)
+	p.Populated = status&0x40 != 0
+
+	// Counts above 254 are reported in the 3.0 "Count 2" words
+	p.CoreCount = countField(s, 0x23, 0x2A)
+	p.CoreEnabled = countField(s, 0x24, 0x2C)
+	p.ThreadCount = countField(s, 0x25, 0x2E)
+	return p, nil
+}
+
+func countField(s *Structure, offset, offset2 int) uint16 {
+	count, _ := s.Byte(offset)
+	if count == 0xFF {
+		if count2, ok := s.Word(offset2); ok {
+			return count2
+		}
+	}
+	return uint16(count)
+}
+
+// Signature decodes the EAX half of ProcessorID.
+func (p ProcessorInformation) Signature() CPUSignature {
+	eax := uint32(p.ProcessorID[0]) | uint32(p.ProcessorID[1])<<8 | uint32(p.ProcessorID[2])<<16 | uint32(p.ProcessorID[3])<<24
+	return CPUSignature{
+		Stepping:       byte(eax & 0xF),
+		Model:          byte(eax >> 4 & 0xF),
+		Family:         byte(eax >> 8 & 0xF),
+		Type:           byte(eax >> 12 & 0x3),
+		ExtendedModel:  byte(eax >> 16 & 0xF),
+		ExtendedFamily: byte(eax >> 20 & 0xFF),
+	}
+}
+
+// FeatureFlags returns the EDX half of ProcessorID.
+func (p ProcessorInformation) FeatureFlags() uint32 {
+	return uint32(p.ProcessorID[4]) | uint32(p.ProcessorID[5])<<8 | uint32(p.ProcessorID[6])<<16 | uint32(p.ProcessorID[7])<<24
+}
+
+// WMIProcessorID formats ProcessorID the way Win32_Processor.ProcessorId does: EDX
+// then EAX, as 16 uppercase hex digits.
+func (p ProcessorInformation) WMIProcessorID() string {
+	eax := uint32(p.ProcessorID[0]) | uint32(p.ProcessorID[1])<<8 | uint32(p.ProcessorID[2])<<16 | uint32(p.ProcessorID[3])<<24
+	return fmt.Sprintf("%08X%08X", p.FeatureFlags(), eax)
+}
+
+// FeatureNames lists the EDX feature flags that are set.
+func (p ProcessorInformation) FeatureNames() []string {
+	var names []string
+	edx := p.FeatureFlags()
+	for bit, name := range cpuFeatures {
+		if name != "" && edx&(1<<bit) != 0 {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// DisplayFamily combines the base and extended family as CPUID documents it.
+func (c CPUSignature) DisplayFamily() int {
+	if c.Family == 0xF {
+		return int(c.Family) + int(c.ExtendedFamily)
+	}
+	return int(c.Family)
+}
+
+// DisplayModel combines the base and extended model as CPUID documents it.
+func (c CPUSignature) DisplayModel() int {
+	if c.Family == 0x6 || c.Family == 0xF {
+		return int(c.ExtendedModel)<<4 + int(c.Model)
+	}
+	return int(c.Model)
+}
+
+func (c CPUSignature) String() string {
+	return fmt.Sprintf("Family 0x%X, Model 0x%X, Stepping %d", c.DisplayFamily(), c.DisplayModel(), c.Stepping)
+}
+
+// Vendor guesses the x86 vendor from the manufacturer string: "Intel", "AMD" or "".
+func (p ProcessorInformation) Vendor() string {
+	m := strings.ToLower(p.Manufacturer)
+	switch {
+	case strings.Contains(m, "intel"):
+		return "Intel"
+	case strings.Contains(m, "amd") || strings.Contains(m, "advanced micro devices"):
+		return "AMD"
+	}
+	return ""
+}
+
+// cpuidVendor returns whose CPUID signature layout a processor family uses, "Intel"
+// or "AMD", or "" for families without one. The ranges follow dmidecode.
+func cpuidVendor(family uint16) string {
+	switch f := family; {
+	case f >= 0x0B && f <= 0x15, // Intel, Cyrix
+		f >= 0x28 && f <= 0x2F, // Intel
+		f >= 0xA1 && f <= 0xB3, // Intel
+		f == 0xB5,              // Intel
+		f >= 0xB9 && f <= 0xC7, // Intel
+		f >= 0xCD && f <= 0xCF, // Intel
+		f >= 0xD2 && f <= 0xDB, // VIA, Intel
+		f >= 0xDD && f <= 0xE0: // Intel
+		return "Intel"
+	case f >= 0x18 && f <= 0x1D, // AMD
+		f == 0x1F,              // AMD
+		f >= 0x38 && f <= 0x3F, // AMD
+		f >= 0x46 && f <= 0x4F, // AMD
+		f >= 0x66 && f <= 0x6B, // AMD
+		f >= 0x83 && f <= 0x8F, // AMD
+		f >= 0xB6 && f <= 0xB7, // AMD
+		f >= 0xE4 && f <= 0xEF: // AMD
+		return "AMD"
+	}
+	return ""
+}
+
+// IsX86 reports whether Family is an x86 family, whose ProcessorID holds a CPUID
+// signature. For "Other" and "Unknown" it falls back to the manufacturer string.
+func (p ProcessorInformation) IsX86() bool {
+	if cpuidVendor(p.Family) != "" {
+		return true
+	}
+	return (p.Family == 0x01 || p.Family == 0x02) && p.Vendor() != ""
+}
+
+// SignatureIssues reports why ProcessorID is not a plausible CPUID signature
+// for the claimed manufacturer. An empty result means nothing looked wrong; it is
+// always empty for non-x86 families, whose ProcessorID is not a CPUID signature.
+func (p ProcessorInformation) SignatureIssues() []string {
+	if !p.IsX86() {
+		return nil
+	}
+
+	var issues []string
+	if p.ProcessorID == [8]byte{} {
+		return []string{"ProcessorID is all zeros"}
+	}
+
+	sig := p.Signature()
+	edx := p.FeatureFlags()
+	if sig.Type == 3 {
+		issues = append(issues, "processor type 3 is reserved")
+	}
+	if sig.ExtendedFamily != 0 && sig.Family != 0xF {
+		issues = append(issues, fmt.Sprintf("extended family 0x%X set with base family 0x%X (only valid with 0xF)", sig.ExtendedFamily, sig.Family))
+	}
+	if sig.ExtendedModel != 0 && sig.Family != 0x6 && sig.Family != 0xF {
+		issues = append(issues, fmt.Sprintf("extended model 0x%X set with base family 0x%X (only valid with 0x6 or 0xF)", sig.ExtendedModel, sig.Family))
+	}
+	for bit, name := range cpuFeatures {
+		if name == "" && edx&(1<<bit) != 0 {
+			issues = append(issues, fmt.Sprintf("reserved EDX feature bit %d is set", bit))
+		}
+	}
+	if edx&1 == 0 {
+		issues = append(issues, "FPU feature flag is clear")
+	}
+
+	switch p.Vendor() {
+	case "Intel":
+		if sig.Family != 0x6 && sig.Family != 0xF && sig.Family != 0x5 && sig.Family != 0x4 {
+			issues = append(issues, fmt.Sprintf("base family 0x%X is not an Intel family", sig.Family))
+		}
+	case "AMD":
+		if sig.Family != 0xF && sig.Family != 0x6 && sig.Family != 0x5 && sig.Family != 0x4 {
+			issues = append(issues, fmt.Sprintf("base family 0x%X is not an AMD family", sig.Family))
+		}
+		if sig.Family == 0x6 && sig.ExtendedModel != 0 {
+			issues = append(issues, "AMD family 0x6 does not use an extended model")
+		}
+	}
+	return issues
+}
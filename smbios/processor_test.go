@@ -0,0 +1,50 @@
+package smbios
+
+import "testing"
+
+func TestProcessorInformationFixture(t *testing.T) {
+	p, err := DecodeProcessorInformation(parseFixture(t, "rsmb_3_2.bin").First(TypeProcessorInformation))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Family != 0xC6 || !p.IsX86() {
+		t.Errorf("family = 0x%X, IsX86 = %v; want 0xC6, true", p.Family, p.IsX86())
+	}
+	if got, want := p.WMIProcessorID(), "BFEBFBFF000A0655"; got != want {
+		t.Errorf("WMIProcessorID = %s, want %s", got, want)
+	}
+	if got, want := p.Signature().String(), "Family 0x6, Model 0xA5, Stepping 5"; got != want {
+		t.Errorf("Signature = %s, want %s", got, want)
+	}
+	if issues := p.SignatureIssues(); len(issues) != 0 {
+		t.Errorf("SignatureIssues = %q, want none", issues)
+	}
+	if p.CoreCount != 8 || p.CoreEnabled != 8 || p.ThreadCount != 16 {
+		t.Errorf("counts = %d/%d/%d, want 8/8/16", p.CoreCount, p.CoreEnabled, p.ThreadCount)
+	}
+}
+
+func TestSignatureIssues(t *testing.T) {
+	// MIDR_EL1 of a Cortex-A72 is not a CPUID signature
+	arm := [8]byte{0x83, 0xD0, 0x0F, 0x41, 0, 0, 0, 0}
+	tests := []struct {
+		name       string
+		p          ProcessorInformation
+		wantIssues bool
+	}{
+		{"Core i7", ProcessorInformation{Family: 0xC6, ProcessorID: [8]byte{0x55, 0x06, 0x0A, 0x00, 0xFF, 0xFB, 0xEB, 0xBF}}, false},
+		{"Core i7, zero ID", ProcessorInformation{Family: 0xC6}, true},
+		{"Ryzen 7", ProcessorInformation{Family: 0x6B, ProcessorID: [8]byte{0x10, 0x0F, 0x87, 0x00, 0xFF, 0xFB, 0x8B, 0x17}}, false},
+		{"ARMv8", ProcessorInformation{Family: 0x101, ProcessorID: arm}, false},
+		{"ARMv8, zero ID", ProcessorInformation{Family: 0x101}, false},
+		{"RISC-V", ProcessorInformation{Family: 0x201, ProcessorID: arm}, false},
+		{"Other, ARM manufacturer", ProcessorInformation{Family: 0x01, Manufacturer: "ARM", ProcessorID: arm}, false},
+		{"Other, Intel manufacturer", ProcessorInformation{Family: 0x01, Manufacturer: "Intel(R) Corporation", ProcessorID: arm}, true},
+	}
+	for _, tt := range tests {
+		issues := tt.p.SignatureIssues()
+		if got := len(issues) != 0; got != tt.wantIssues {
+			t.Errorf("%s: SignatureIssues = %q, want issues: %v", tt.name, issues, tt.wantIssues)
+		}
+	}
+}
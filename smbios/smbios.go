@@ -0,0 +1,254 @@
+// Package smbios parses raw SMBIOS structure tables into typed structures.
+// It is pure Go (no unsafe, no OS calls) so firmware tables captured on one
+// machine can be decoded anywhere, including on Linux.
+package smbios
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// SMBIOS structure types
+const (
+	TypeBIOSInformation      = 0
+	TypeSystemInformation    = 1
+	TypeBaseboard            = 2
+	TypeChassis              = 3
+	TypeProcessorInformation = 4
+	TypeOEMStrings           = 11
+	TypeConfigurationOptions = 12
+	TypeMemoryDevice         = 17
+	TypeEndOfTable           = 127
+)
+
+const (
+	// HeaderSize is the size of the structure header (type, length, handle).
+	HeaderSize = 4
+	// RawHeaderSize is the size of the RawSMBIOSData header that precedes the
+	// structure table in GetSystemFirmwareTable('RSMB') output.
+	RawHeaderSize = 8
+)
+
+var (
+	ErrNoString   = errors.New("string not set")
+	ErrFieldRange = errors.New("field outside formatted area")
+)
+
+// Structure is one SMBIOS structure: its header, formatted area and string set.
+type Structure struct {
+	Type   uint8
+	Length uint8
+	Handle uint16
+	// Offset is the position of the structure within the table data.
+	Offset int
+	// Formatted is the formatted area, including the 4-byte header, so field
+	// offsets from the specification can be used directly.
+	Formatted []byte
+	Strings   []string
+}
+
+// Table is a parsed RawSMBIOSData buffer.
+type Table struct {
+	Used20CallingMethod byte
+	MajorVersion        byte
+	MinorVersion        byte
+	DmiRevision         byte
+	Length              uint32
+	// Data is the raw structure table, limited to Length bytes.
+	Data       []byte
+	Structures []Structure
+}
+
+// ParseRawSMBIOSData parses a buffer laid out like RawSMBIOSData: an 8-byte
+// header carrying the version fields and table length, followed by the table.
+func ParseRawSMBIOSData(buf []byte) (*Table, error) {
+	if len(buf) < RawHeaderSize {
+		return nil, fmt.Errorf("received truncated SMBIOS data (less than header size)")
+	}
+
+	t := &Table{
+		Used20CallingMethod: buf[0],
+		MajorVersion:        buf[1],
+		MinorVersion:        buf[2],
+		DmiRevision:         buf[3],
+		Length:              binary.LittleEndian.Uint32(buf[4:8]),
+	}
+
+	tableData := buf[RawHeaderSize:]
+	if int(t.Length) > len(tableData) {
+		return nil, fmt.Errorf("SMBIOS reported length (%d) exceeds buffer capacity (%d)", t.Length, len(tableData))
+	}
+	t.Data = tableData[:t.Length]
+
+	structures, err := ParseTable(t.Data)
+	if err != nil {
+		return nil, err
+	}
+	t.Structures = structures
+	return t, nil
+}
+
+// ParseTable walks the structure table and returns every structure up to and
+// including the end-of-table marker (Type 127), or up to the end of data.
+func ParseTable(data []byte) ([]Structure, error) {
+	var structures []Structure
+	offset := 0
+	for offset+HeaderSize <= len(data) {
+		s, next, err := parseStructure(data, offset)
+		if err != nil {
+			return structures, err
+		}
+		structures = append(structures, s)
+		if s.Type == TypeEndOfTable {
+			break
+		}
+		offset = next
+	}
+	return structures, nil
+}
+
+// parseStructure decodes the structure at offset and returns it together with
+// the offset of the structure that follows it.
+func parseStructure(data []byte, offset int) (Structure, int, error) {
+	length := int(data[offset+1])
+	if length < HeaderSize {
+		return Structure{}, 0, fmt.Errorf("structure at offset 0x%X has invalid length %d", offset, length)
+	}
+	if offset+length > len(data) {
+		return Structure{}, 0, fmt.Errorf("structure at offset 0x%X (type %d) runs past end of table", offset, data[offset])
+	}
+
+	s := Structure{
+		Type:      data[offset],
+		Length:    uint8(length),
+		Handle:    binary.LittleEndian.Uint16(data[offset+2 : offset+4]),
+		Offset:    offset,
+		Formatted: data[offset : offset+length],
+	}
+
+	strings, next, err := parseStrings(data, offset+length)
+	if err != nil {
+		return Structure{}, 0, fmt.Errorf("structure at offset 0x%X (type %d): %w", offset, s.Type, err)
+	}
+	s.Strings = strings
+	return s, next, nil
+}
+
+// parseStrings reads the string set starting at offset. The set is a list of
+// NUL-terminated strings ended by an extra NUL; an empty set is two NULs.
+func parseStrings(data []byte, offset int) ([]string, int, error) {
+	if offset+1 < len(data) && data[offset] == 0 && data[offset+1] == 0 {
+		return nil, offset + 2, nil
+	}
+
+	var strings []string
+	for offset < len(data) {
+		if data[offset] == 0 {
+			return strings, offset + 1, nil
+		}
+		end := offset
+		for end < len(data) && data[end] != 0 {
+			end++
+		}
+		if end == len(data) {
+			break
+		}
+		strings = append(strings, string(data[offset:end]))
+		offset = end + 1
+	}
+	return nil, 0, fmt.Errorf("unterminated string set")
+}
+
+// First returns the first structure of the given type, or nil.
+func (t *Table) First(typ uint8) *Structure {
+	for i := range t.Structures {
+		if t.Structures[i].Type == typ {
+			return &t.Structures[i]
+		}
+	}
+	return nil
+}
+
+// All returns every structure of the given type in table order.
+func (t *Table) All(typ uint8) []*Structure {
+	var out []*Structure
+	for i := range t.Structures {
+		if t.Structures[i].Type == typ {
+			out = append(out, &t.Structures[i])
+		}
+	}
+	return out
+}
+
+// Byte returns the byte at offset in the formatted area.
+func (s *Structure) Byte(offset int) (byte, bool) {
+	if offset < 0 || offset >= len(s.Formatted) {
+		return 0, false
+	}
+	return s.Formatted[offset], true
+}
+
+// Word returns the little-endian uint16 at offset in the formatted area.
+func (s *Structure) Word(offset int) (uint16, bool) {
+	b, ok := s.Bytes(offset, 2)
+	if !ok {
+		return 0, false
+	}
+	return binary.LittleEndian.Uint16(b), true
+}
+
+// DWord returns the little-endian uint32 at offset in the formatted area.
+func (s *Structure) DWord(offset int) (uint32, bool) {
+	b, ok := s.Bytes(offset, 4)
+	if !ok {
+		return 0, false
+	}
+	return binary.LittleEndian.Uint32(b), true
+}
+
+// QWord returns the little-endian uint64 at offset in the formatted area.
+func (s *Structure) QWord(offset int) (uint64, bool) {
+	b, ok := s.Bytes(offset, 8)
+	if !ok {
+		return 0, false
+	}
+	return binary.LittleEndian.Uint64(b), true
+}
+
+// Bytes returns n bytes starting at offset in the formatted area.
+func (s *Structure) Bytes(offset, n int) ([]byte, bool) {
+	if offset < 0 || n < 0 || offset+n > len(s.Formatted) {
+		return nil, false
+	}
+	return s.Formatted[offset : offset+n], true
+}
+
+// GetString resolves the string-index byte at offset in the formatted area.
+// It returns ErrNoString when the index is 0 (field not set).
+func (s *Structure) GetString(offset int) (string, error) {
+	index, ok := s.Byte(offset)
+	if !ok {
+		return "", fmt.Errorf("type %d string field at 0x%02X: %w", s.Type, offset, ErrFieldRange)
+	}
+	if index == 0 {
+		return "", ErrNoString
+	}
+	if int(index) > len(s.Strings) {
+		return "", fmt.Errorf("string index %d out of bounds (%d strings)", index, len(s.Strings))
+	}
+	return s.Strings[index-1], nil
+}
+
+// BadIndex stands in for string fields whose index points past the string set.
+const BadIndex = "<BAD INDEX>"
+
+// stringField is GetString for decoders: unset or absent fields decode to ""
+// and out-of-range indexes to BadIndex.
+func (s *Structure) stringField(offset int) string {
+	v, err := s.GetString(offset)
+	if err != nil && !errors.Is(err, ErrNoString) && !errors.Is(err, ErrFieldRange) {
+		return BadIndex
+	}
+	return v
+}
@@ -0,0 +1,107 @@
+package smbios
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// readFixture returns a RawSMBIOSData capture from testdata.
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	raw, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return raw
+}
+
+func parseFixture(t *testing.T, name string) *Table {
+	t.Helper()
+	table, err := ParseRawSMBIOSData(readFixture(t, name))
+	if err != nil {
+		t.Fatalf("ParseRawSMBIOSData(%s): %v", name, err)
+	}
+	return table
+}
+
+func TestParseRawSMBIOSData(t *testing.T) {
+	table := parseFixture(t, "rsmb_3_2.bin")
+	if table.MajorVersion != 3 || table.MinorVersion != 2 || table.DmiRevision != 0 {
+		t.Errorf("version = %d.%d.%d, want 3.2.0", table.MajorVersion, table.MinorVersion, table.DmiRevision)
+	}
+	if got, want := len(table.Structures), 21; got != want {
+		t.Fatalf("got %d structures, want %d", got, want)
+	}
+	if last := table.Structures[len(table.Structures)-1]; last.Type != TypeEndOfTable {
+		t.Errorf("last structure is type %d, want %d", last.Type, TypeEndOfTable)
+	}
+	if got := len(table.All(7)); got != 3 {
+		t.Errorf("got %d cache structures, want 3", got)
+	}
+
+	tests := []struct {
+		typ    uint8
+		offset int
+		want   string
+	}{
+		{TypeBIOSInformation, 0x04, "Dell Inc."},
+		{TypeBIOSInformation, 0x08, "06/14/2022"},
+		{TypeSystemInformation, 0x05, "OptiPlex 7080"},
+		{TypeSystemInformation, 0x07, "8XQ4LN3"},
+		{TypeBaseboard, 0x07, "/8XQ4LN3/CNFCW0009P00BH/"},
+		{TypeProcessorInformation, 0x10, "Intel(R) Core(TM) i7-10700 CPU @ 2.90GHz"},
+	}
+	for _, tt := range tests {
+		got, err := table.First(tt.typ).GetString(tt.offset)
+		if err != nil || got != tt.want {
+			t.Errorf("type %d string at 0x%02X = %q, %v; want %q", tt.typ, tt.offset, got, err, tt.want)
+		}
+	}
+
+	if _, err := table.First(TypeSystemInformation).GetString(0x06); err != ErrNoString {
+		t.Errorf("unset Type 1 version: err = %v, want ErrNoString", err)
+	}
+}
+
+func TestParseRawSMBIOSDataTruncated(t *testing.T) {
+	if _, err := ParseRawSMBIOSData(readFixture(t, "truncated_structure.bin")); err == nil {
+		t.Error("structure cut off inside its formatted area parsed without error")
+	}
+
+	raw := readFixture(t, "rsmb_3_2.bin")
+	if _, err := ParseRawSMBIOSData(raw[:RawHeaderSize-1]); err == nil {
+		t.Error("buffer shorter than the RawSMBIOSData header parsed without error")
+	}
+	// The header still claims the full length
+	if _, err := ParseRawSMBIOSData(raw[:len(raw)-10]); err == nil {
+		t.Error("buffer shorter than the header's table length parsed without error")
+	}
+}
+
+func TestParseRawSMBIOSDataMissingTerminator(t *testing.T) {
+	_, err := ParseRawSMBIOSData(readFixture(t, "missing_terminator.bin"))
+	if err == nil {
+		t.Fatal("string set without its double-NUL terminator parsed without error")
+	}
+}
+
+func TestSystemUUIDByteOrder(t *testing.T) {
+	const want = "4C4C4544-0058-5110-8034-B8C04F4C4E33"
+	// SMBIOS 2.6 and later store the first three fields little-endian; 2.4 stores
+	// the same UUID in network order
+	for _, name := range []string{"rsmb_3_2.bin", "rsmb_2_4.bin"} {
+		table := parseFixture(t, name)
+		system, err := DecodeSystemInformation(table.First(TypeSystemInformation))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		uuid, err := DecodeUUID(system.UUID[:], table.MajorVersion, table.MinorVersion)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if uuid.String() != want {
+			t.Errorf("%s: UUID = %s, want %s", name, uuid, want)
+		}
+	}
+}
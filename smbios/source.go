@@ -0,0 +1,116 @@
+package smbios
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// DefaultSysfsDir is where Linux exposes the DMI table and its entry point.
+const DefaultSysfsDir = "/sys/firmware/dmi/tables"
+
+// Source supplies a RawSMBIOSData buffer: the 8-byte header followed by the
+// structure table, exactly as GetSystemFirmwareTable('RSMB') returns it.
+type Source interface {
+	Name() string
+	ReadRaw() ([]byte, error)
+}
+
+// FileSource reads a dump file holding a RawSMBIOSData header plus the table.
+type FileSource struct {
+	Path string
+}
+
+func (f FileSource) Name() string {
+	return "file:" + f.Path
+}
+
+func (f FileSource) ReadRaw() ([]byte, error) {
+	data, err := os.ReadFile(f.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SMBIOS dump: %w", err)
+	}
+	if len(data) < RawHeaderSize {
+		return nil, fmt.Errorf("SMBIOS dump %s is too short (%d bytes)", f.Path, len(data))
+	}
+	return data, nil
+}
+
+// SysfsSource reads the DMI table and entry point exported by Linux and
+// rebuilds a RawSMBIOSData buffer from them.
+type SysfsSource struct {
+	// Dir defaults to DefaultSysfsDir when empty.
+	Dir string
+}
+
+func (s SysfsSource) Name() string {
+	return "sysfs:" + s.dir()
+}
+
+func (s SysfsSource) dir() string {
+	if s.Dir == "" {
+		return DefaultSysfsDir
+	}
+	return s.Dir
+}
+
+func (s SysfsSource) ReadRaw() ([]byte, error) {
+	entryPoint, err := os.ReadFile(filepath.Join(s.dir(), "smbios_entry_point"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SMBIOS entry point: %w", err)
+	}
+	table, err := os.ReadFile(filepath.Join(s.dir(), "DMI"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read DMI table: %w", err)
+	}
+
+	major, minor, revision, err := parseEntryPoint(entryPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := make([]byte, RawHeaderSize, RawHeaderSize+len(table))
+	raw[1] = major
+	raw[2] = minor
+	raw[3] = revision
+	binary.LittleEndian.PutUint32(raw[4:8], uint32(len(table)))
+	return append(raw, table...), nil
+}
+
+// parseEntryPoint extracts the version fields from a 32-bit (_SM_), 64-bit
+// (_SM3_) or legacy (_DMI_) entry point structure.
+func parseEntryPoint(ep []byte) (major, minor, revision byte, err error) {
+	switch {
+	case bytes.HasPrefix(ep, []byte("_SM3_")):
+		if len(ep) < 0x18 {
+			return 0, 0, 0, fmt.Errorf("64-bit entry point too short (%d bytes)", len(ep))
+		}
+		return ep[0x07], ep[0x08], ep[0x09], nil
+	case bytes.HasPrefix(ep, []byte("_SM_")):
+		if len(ep) < 0x1F {
+			return 0, 0, 0, fmt.Errorf("32-bit entry point too short (%d bytes)", len(ep))
+		}
+		return ep[0x06], ep[0x07], ep[0x1E], nil
+	case bytes.HasPrefix(ep, []byte("_DMI_")):
+		if len(ep) < 0x0F {
+			return 0, 0, 0, fmt.Errorf("legacy entry point too short (%d bytes)", len(ep))
+		}
+		// Legacy entry points only carry a BCD revision
+		return ep[0x0E] >> 4, ep[0x0E] & 0x0F, ep[0x0E], nil
+	}
+	return 0, 0, 0, fmt.Errorf("unrecognised SMBIOS entry point anchor %q", ep[:min(len(ep), 5)])
+}
+
+// WriteDump writes a RawSMBIOSData buffer to path unchanged, so it can be
+// replayed later through FileSource.
+func WriteDump(path string, raw []byte) error {
+	if len(raw) < RawHeaderSize {
+		return fmt.Errorf("refusing to write truncated SMBIOS buffer (%d bytes)", len(raw))
+	}
+	if err := os.WriteFile(path, raw, 0o644); err != nil {
+		return fmt.Errorf("failed to write SMBIOS dump: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package smbios
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// entryPoint21 builds a 31-byte SMBIOS 2.1 (_SM_) entry point.
+func entryPoint21(major, minor, bcdRevision byte) []byte {
+	ep := make([]byte, 0x1F)
+	copy(ep, "_SM_")
+	ep[0x05] = 0x1F
+	ep[0x06], ep[0x07] = major, minor
+	copy(ep[0x10:], "_DMI_")
+	ep[0x1E] = bcdRevision
+	return ep
+}
+
+// entryPoint30 builds a 24-byte SMBIOS 3.0 (_SM3_) entry point.
+func entryPoint30(major, minor, docrev byte) []byte {
+	ep := make([]byte, 0x18)
+	copy(ep, "_SM3_")
+	ep[0x06] = 0x18
+	ep[0x07], ep[0x08], ep[0x09] = major, minor, docrev
+	ep[0x0A] = 0x01
+	return ep
+}
+
+func TestParseEntryPoint(t *testing.T) {
+	legacy := make([]byte, 0x0F)
+	copy(legacy, "_DMI_")
+	legacy[0x0E] = 0x20
+
+	tests := []struct {
+		name                   string
+		ep                     []byte
+		major, minor, revision byte
+	}{
+		{"2.1 entry point", entryPoint21(2, 8, 0x28), 2, 8, 0x28},
+		{"3.0 entry point", entryPoint30(3, 2, 0), 3, 2, 0},
+		{"3.0 entry point with docrev", entryPoint30(3, 3, 1), 3, 3, 1},
+		{"legacy entry point", legacy, 2, 0, 0x20},
+	}
+	for _, tt := range tests {
+		major, minor, revision, err := parseEntryPoint(tt.ep)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if major != tt.major || minor != tt.minor || revision != tt.revision {
+			t.Errorf("%s: version = %d.%d.%d, want %d.%d.%d", tt.name, major, minor, revision, tt.major, tt.minor, tt.revision)
+		}
+	}
+
+	for name, ep := range map[string][]byte{
+		"short 2.1 entry point": entryPoint21(2, 8, 0x28)[:0x1E],
+		"short 3.0 entry point": entryPoint30(3, 2, 0)[:0x17],
+		"short legacy":          legacy[:0x0E],
+		"unknown anchor":        []byte("_XX_ entry point"),
+		"empty":                 nil,
+	} {
+		if _, _, _, err := parseEntryPoint(ep); err == nil {
+			t.Errorf("%s parsed without error", name)
+		}
+	}
+}
+
+func TestSysfsSource(t *testing.T) {
+	raw := readFixture(t, "rsmb_3_2.bin")
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "smbios_entry_point"), entryPoint30(3, 2, 0), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "DMI"), raw[RawHeaderSize:], 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The rebuilt buffer matches the RSMB capture of the same table
+	got, err := SysfsSource{Dir: dir}.ReadRaw()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, raw) {
+		t.Errorf("sysfs buffer differs from the RSMB capture: header % X, want % X", got[:RawHeaderSize], raw[:RawHeaderSize])
+	}
+
+	if _, err := (SysfsSource{Dir: t.TempDir()}).ReadRaw(); err == nil {
+		t.Error("empty sysfs directory read without error")
+	}
+	if got := (SysfsSource{}).Name(); got != "sysfs:"+DefaultSysfsDir {
+		t.Errorf("Name = %q, want sysfs:%s", got, DefaultSysfsDir)
+	}
+}
+
+func TestFileSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rsmb.bin")
+	raw := readFixture(t, "rsmb_2_4.bin")
+	if err := WriteDump(path, raw); err != nil {
+		t.Fatal(err)
+	}
+	got, err := FileSource{Path: path}.ReadRaw()
+	if err != nil || !bytes.Equal(got, raw) {
+		t.Errorf("FileSource.ReadRaw = %d bytes, %v; want the %d bytes written", len(got), err, len(raw))
+	}
+	if err := WriteDump(path, raw[:RawHeaderSize-1]); err == nil {
+		t.Error("WriteDump wrote a truncated buffer")
+	}
+}
@@ -0,0 +1,72 @@
+package smbios
+
+import (
+	"fmt"
+)
+
+// SystemInformation is a decoded Type 1 structure.
+type SystemInformation struct {
+	Handle       uint16
+	Manufacturer string
+	ProductName  string
+	Version      string
+	SerialNumber string
+	// UUID holds the raw 16 bytes as stored in the table; HasUUID is false
+	// for pre-2.1 structures without the field.
+	UUID    [16]byte
+	HasUUID bool
+	// HasWakeUpType and HasSKUAndFamily are false for structures that end
+	// before those fields (pre-2.1 and pre-2.4 respectively).
+	HasWakeUpType   bool
+	WakeUpType      byte
+	HasSKUAndFamily bool
+	SKUNumber       string
+	Family          string
+}
+
+var wakeUpTypes = []string{
+	"Reserved",
+	"Other",
+	"Unknown",
+	"APM Timer",
+	"Modem Ring",
+	"LAN Remote",
+	"Power Switch",
+	"PCI PME#",
+	"AC Power Restored",
+}
+
+// DecodeSystemInformation decodes a Type 1 structure.
+func DecodeSystemInformation(s *Structure) (SystemInformation, error) {
+	if s.Type != TypeSystemInformation {
+		return SystemInformation{}, fmt.Errorf("structure 0x%04X is type %d, not system information", s.Handle, s.Type)
+	}
+	if s.Length < 0x08 {
+		return SystemInformation{}, fmt.Errorf("system information structure (Type 1) too short (%d bytes)", s.Length)
+	}
+
+	si := SystemInformation{
+		Handle:       s.Handle,
+		Manufacturer: s.stringField(0x04),
+		ProductName:  s.stringField(0x05),
+		Version:      s.stringField(0x06),
+		SerialNumber: s.stringField(0x07),
+		SKUNumber:    s.stringField(0x19),
+		Family:       s.stringField(0x1A),
+	}
+	if uuid, ok := s.Bytes(0x08, 16); ok {
+		copy(si.UUID[:], uuid)
+		si.HasUUID = true
+	}
+	si.WakeUpType, si.HasWakeUpType = s.Byte(0x18)
+	si.HasSKUAndFamily = s.Length >= 0x1B
+	return si, nil
+}
+
+// WakeUpTypeString names the wake-up type, e.g. "Power Switch".
+func (si SystemInformation) WakeUpTypeString() string {
+	if int(si.WakeUpType) < len(wakeUpTypes) {
+		return wakeUpTypes[si.WakeUpType]
+	}
+	return fmt.Sprintf("Unknown (0x%02X)", si.WakeUpType)
+}
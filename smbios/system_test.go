@@ -0,0 +1,38 @@
+package smbios
+
+import "testing"
+
+func TestSystemInformationFixtures(t *testing.T) {
+	tests := []struct {
+		fixture         string
+		hasSKUAndFamily bool
+		family          string
+		wakeUpType      string
+	}{
+		{"rsmb_3_2.bin", true, "OptiPlex", "Power Switch"},
+		{"rsmb_2_4.bin", false, "", "Power Switch"},
+	}
+	for _, tt := range tests {
+		system, err := DecodeSystemInformation(parseFixture(t, tt.fixture).First(TypeSystemInformation))
+		if err != nil {
+			t.Fatalf("%s: %v", tt.fixture, err)
+		}
+		if system.HasSKUAndFamily != tt.hasSKUAndFamily || system.Family != tt.family {
+			t.Errorf("%s: family = %v %q, want %v %q", tt.fixture, system.HasSKUAndFamily, system.Family, tt.hasSKUAndFamily, tt.family)
+		}
+		if !system.HasUUID || !system.HasWakeUpType || system.WakeUpTypeString() != tt.wakeUpType {
+			t.Errorf("%s: wake-up type = %v %q, want %q", tt.fixture, system.HasWakeUpType, system.WakeUpTypeString(), tt.wakeUpType)
+		}
+	}
+}
+
+func TestSystemInformationShort(t *testing.T) {
+	// A 2.0 structure has only the four strings
+	system, err := DecodeSystemInformation(structure(TypeSystemInformation, 1, 2, 3, 4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if system.HasUUID || system.HasWakeUpType || system.HasSKUAndFamily {
+		t.Errorf("2.0 structure decoded fields beyond its length: %+v", system)
+	}
+}
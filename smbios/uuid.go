@@ -0,0 +1,78 @@
+package smbios
+
+import (
+	"fmt"
+)
+
+// UUID is a system UUID in RFC 4122 (big-endian) byte order.
+type UUID [16]byte
+
+// UUIDByteSwapped reports whether a table of the given version stores the
+// first three UUID fields little-endian. SMBIOS mandates that from 2.6 on;
+// earlier tables store the bytes in network order.
+func UUIDByteSwapped(major, minor byte) bool {
+	return major > 2 || (major == 2 && minor >= 6)
+}
+
+// DecodeUUID converts the 16 raw bytes of a Type 1 UUID field into RFC 4122
+// byte order according to the table version.
+func DecodeUUID(raw []byte, major, minor byte) (UUID, error) {
+	var u UUID
+	if len(raw) != 16 {
+		return u, fmt.Errorf("UUID field must be 16 bytes, got %d", len(raw))
+	}
+	copy(u[:], raw)
+	if UUIDByteSwapped(major, minor) {
+		u[0], u[1], u[2], u[3] = raw[3], raw[2], raw[1], raw[0]
+		u[4], u[5] = raw[5], raw[4]
+		u[6], u[7] = raw[7], raw[6]
+	}
+	return u, nil
+}
+
+func (u UUID) String() string {
+	return fmt.Sprintf("%02X%02X%02X%02X-%02X%02X-%02X%02X-%02X%02X-%02X%02X%02X%02X%02X%02X",
+		u[0], u[1], u[2], u[3], u[4], u[5], u[6], u[7],
+		u[8], u[9], u[10], u[11], u[12], u[13], u[14], u[15])
+}
+
+// IsAbsent reports the all-0x00 value: no UUID is present in the system and
+// none can be set.
+func (u UUID) IsAbsent() bool {
+	return u == UUID{}
+}
+
+// IsNotSet reports the all-0xFF value: no UUID is currently present in the
+// system, but one can be set.
+func (u UUID) IsNotSet() bool {
+	for _, b := range u {
+		if b != 0xFF {
+			return false
+		}
+	}
+	return true
+}
+
+// Version returns the RFC 4122 version nibble.
+func (u UUID) Version() int {
+	return int(u[6] >> 4)
+}
+
+// Variant names the variant encoded in the top bits of byte 8.
+func (u UUID) Variant() string {
+	switch {
+	case u[8]&0x80 == 0:
+		return "NCS"
+	case u[8]&0xC0 == 0x80:
+		return "RFC 4122"
+	case u[8]&0xE0 == 0xC0:
+		return "Microsoft"
+	}
+	return "Reserved"
+}
+
+// WellFormed reports whether u carries the RFC 4122 variant and a defined
+// version (1-8).
+func (u UUID) WellFormed() bool {
+	return u.Variant() == "RFC 4122" && u.Version() >= 1 && u.Version() <= 8
+}
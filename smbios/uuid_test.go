@@ -0,0 +1,79 @@
+package smbios
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestUUIDSpecialValues(t *testing.T) {
+	var zeros, ones UUID
+	for i := range ones {
+		ones[i] = 0xFF
+	}
+	// One cleared bit makes it neither special value
+	partial := ones
+	partial[15] = 0xFE
+
+	tests := []struct {
+		name           string
+		u              UUID
+		absent, notSet bool
+	}{
+		{"all 0x00", zeros, true, false},
+		{"all 0xFF", ones, false, true},
+		{"partial 0xFF", partial, false, false},
+	}
+	for _, tt := range tests {
+		if got := tt.u.IsAbsent(); got != tt.absent {
+			t.Errorf("%s: IsAbsent = %v, want %v", tt.name, got, tt.absent)
+		}
+		if got := tt.u.IsNotSet(); got != tt.notSet {
+			t.Errorf("%s: IsNotSet = %v, want %v", tt.name, got, tt.notSet)
+		}
+	}
+}
+
+func TestUUIDVersionAndVariant(t *testing.T) {
+	tests := []struct {
+		uuid       string
+		version    int
+		variant    string
+		wellFormed bool
+	}{
+		// Dell derives its UUIDs from the service tag as version 5
+		{"4C4C4544-0058-5110-8034-B8C04F4C4E33", 5, "RFC 4122", true},
+		{"6DE5D951-D755-576B-BD09-C5CF66B27234", 5, "RFC 4122", true},
+		{"3F2504E0-4F89-41D3-9A0C-0305E82C3301", 4, "RFC 4122", true},
+		{"01234567-89AB-8DEF-8123-456789ABCDEF", 8, "RFC 4122", true},
+		{"01234567-89AB-0DEF-8123-456789ABCDEF", 0, "RFC 4122", false},
+		{"01234567-89AB-9DEF-8123-456789ABCDEF", 9, "RFC 4122", false},
+		{"01234567-89AB-4DEF-0123-456789ABCDEF", 4, "NCS", false},
+		{"01234567-89AB-4DEF-C123-456789ABCDEF", 4, "Microsoft", false},
+		{"01234567-89AB-4DEF-E123-456789ABCDEF", 4, "Reserved", false},
+	}
+	for _, tt := range tests {
+		u := parseUUID(t, tt.uuid)
+		if got := u.Version(); got != tt.version {
+			t.Errorf("%s: Version = %d, want %d", tt.uuid, got, tt.version)
+		}
+		if got := u.Variant(); got != tt.variant {
+			t.Errorf("%s: Variant = %q, want %q", tt.uuid, got, tt.variant)
+		}
+		if got := u.WellFormed(); got != tt.wellFormed {
+			t.Errorf("%s: WellFormed = %v, want %v", tt.uuid, got, tt.wellFormed)
+		}
+	}
+}
+
+// parseUUID reads the String form back into a UUID.
+func parseUUID(t *testing.T, s string) UUID {
+	t.Helper()
+	var u UUID
+	raw, err := hex.DecodeString(strings.ReplaceAll(s, "-", ""))
+	if err != nil || len(raw) != len(u) {
+		t.Fatalf("malformed UUID %q", s)
+	}
+	copy(u[:], raw)
+	return u
+}
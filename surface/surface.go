@@ -0,0 +1,155 @@
+// Package surface runs every retrieval path registered for a logical identifier (the
+// computer name, the BIOS serial, ...) and reports which of the paths agree, so a
+// spoofer that covers only some call paths shows up as a split.
+package surface
+
+import "strings"
+
+// Layer is the level of the system a path reads through.
+type Layer string
+
+const (
+	Win32    Layer = "Win32"
+	NT       Layer = "NT"
+	WMI      Layer = "WMI"
+	Registry Layer = "Registry"
+	Raw      Layer = "Raw"
+)
+
+// Charset is the string encoding the export a path calls works in.
+type Charset string
+
+const (
+	ANSI    Charset = "A"
+	Unicode Charset = "W"
+	// Binary marks exports that return bytes the caller formats itself.
+	Binary Charset = "-"
+)
+
+// Path is one way of retrieving an identifier.
+type Path struct {
+	DLL     string
+	Export  string
+	Charset Charset
+	Layer   Layer
+	// Detail tells apart paths through the same export, such as two WMI queries or
+	// two registry values.
+	Detail string
+	Read   func() (string, error)
+}
+
+// Name renders the path as "dll!Export (detail)".
+func (p Path) Name() string {
+	name := p.DLL + "!" + p.Export
+	if p.Detail != "" {
+		name += " (" + p.Detail + ")"
+	}
+	return name
+}
+
+// Identifier is one logical identifier and every path registered for it.
+type Identifier struct {
+	Name  string
+	Paths []Path
+	// Equal reports whether two values agree; nil compares them with surrounding
+	// whitespace trimmed.
+	Equal func(a, b string) bool
+}
+
+// Result is what one path returned.
+type Result struct {
+	Path  Path
+	Value string
+	Err   error
+	// Group numbers the distinct values in the order they were first seen: results
+	// in the same group agree. It is -1 for failed reads.
+	Group int
+}
+
+// Matrix is the outcome of running every path of an identifier.
+type Matrix struct {
+	Identifier string
+	Results    []Result
+	// Groups is how many distinct values the successful reads returned.
+	Groups int
+}
+
+// Run calls every path of id and groups the values that agree.
+func (id Identifier) Run() Matrix {
+	equal := id.Equal
+	if equal == nil {
+		equal = func(a, b string) bool { return strings.TrimSpace(a) == strings.TrimSpace(b) }
+	}
+
+	m := Matrix{Identifier: id.Name, Results: make([]Result, 0, len(id.Paths))}
+	var representatives []string
+	for _, path := range id.Paths {
+		r := Result{Path: path, Group: -1}
+		r.Value, r.Err = path.Read()
+		if r.Err == nil {
+			for group, value := range representatives {
+				if equal(value, r.Value) {
+					r.Group = group
+					break
+				}
+			}
+			if r.Group < 0 {
+				r.Group = len(representatives)
+				representatives = append(representatives, r.Value)
+			}
+		}
+		m.Results = append(m.Results, r)
+	}
+	m.Groups = len(representatives)
+	return m
+}
+
+// Agrees reports whether every successful read returned the same value.
+func (m Matrix) Agrees() bool {
+	return m.Groups <= 1
+}
+
+// Failed returns how many paths returned an error.
+func (m Matrix) Failed() int {
+	failed := 0
+	for _, r := range m.Results {
+		if r.Group < 0 {
+			failed++
+		}
+	}
+	return failed
+}
+
+// Majority returns the group most paths returned, preferring the earliest group on a
+// tie, or -1 when every read failed.
+func (m Matrix) Majority() int {
+	counts := make([]int, m.Groups)
+	for _, r := range m.Results {
+		if r.Group >= 0 {
+			counts[r.Group]++
+		}
+	}
+	majority := -1
+	for group, count := range counts {
+		if majority < 0 || count > counts[majority] {
+			majority = group
+		}
+	}
+	return majority
+}
+
+// Split lists the layers whose paths disagree with the majority value, in the order
+// their first dissenting path was registered.
+func (m Matrix) Split() []Layer {
+	majority := m.Majority()
+	var layers []Layer
+	seen := make(map[Layer]bool)
+	for _, r := range m.Results {
+		if r.Group < 0 || r.Group == majority || seen[r.Path.Layer] {
+			continue
+		}
+		seen[r.Path.Layer] = true
+		layers = append(layers, r.Path.Layer)
+	}
+	return layers
+}
@@ -0,0 +1,108 @@
+package surface
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// fixed returns a path that always reads value, or fails with err when err is set.
+func fixed(layer Layer, value string, err error) Path {
+	return Path{DLL: "kernel32.dll", Export: "Get", Layer: layer, Read: func() (string, error) { return value, err }}
+}
+
+func groups(m Matrix) []int {
+	var g []int
+	for _, r := range m.Results {
+		g = append(g, r.Group)
+	}
+	return g
+}
+
+func TestRunGroups(t *testing.T) {
+	failure := errors.New("access denied")
+	m := Identifier{Name: "Serial", Paths: []Path{
+		fixed(Win32, "ABC ", nil),
+		fixed(WMI, "spoofed", nil),
+		fixed(NT, "ABC", nil),
+		fixed(Raw, "", failure),
+		fixed(Registry, "abc", nil),
+	}}.Run()
+
+	// Surrounding whitespace is trimmed by default, case is not folded
+	if want := []int{0, 1, 0, -1, 2}; !slices.Equal(groups(m), want) {
+		t.Errorf("groups = %v, want %v", groups(m), want)
+	}
+	if m.Identifier != "Serial" || m.Groups != 3 || m.Agrees() {
+		t.Errorf("Identifier %q, Groups %d, Agrees %v", m.Identifier, m.Groups, m.Agrees())
+	}
+	if m.Failed() != 1 || !errors.Is(m.Results[3].Err, failure) {
+		t.Errorf("Failed = %d, err %v; want 1, %v", m.Failed(), m.Results[3].Err, failure)
+	}
+	if m.Majority() != 0 {
+		t.Errorf("Majority = %d, want 0", m.Majority())
+	}
+
+	m = Identifier{Paths: []Path{fixed(Win32, "abc", nil), fixed(WMI, "ABC", nil)}, Equal: strings.EqualFold}.Run()
+	if !m.Agrees() || m.Groups != 1 {
+		t.Errorf("custom Equal: Groups = %d, want 1", m.Groups)
+	}
+}
+
+func TestMajorityTie(t *testing.T) {
+	m := Identifier{Paths: []Path{
+		fixed(WMI, "b", nil),
+		fixed(Win32, "a", nil),
+		fixed(Win32, "a", nil),
+		fixed(WMI, "b", nil),
+	}}.Run()
+	// Two paths each; the group seen first wins
+	if m.Majority() != 0 || m.Results[0].Value != "b" {
+		t.Errorf("Majority = %d, want 0 (%q)", m.Majority(), m.Results[0].Value)
+	}
+	if split := m.Split(); !slices.Equal(split, []Layer{Win32}) {
+		t.Errorf("Split = %v, want [Win32]", split)
+	}
+}
+
+func TestAllFailed(t *testing.T) {
+	m := Identifier{Paths: []Path{fixed(Win32, "", errors.New("a")), fixed(NT, "", errors.New("b"))}}.Run()
+	if m.Groups != 0 || m.Failed() != 2 || m.Majority() != -1 {
+		t.Errorf("Groups %d, Failed %d, Majority %d; want 0, 2, -1", m.Groups, m.Failed(), m.Majority())
+	}
+	// Nothing read, so nothing disagrees
+	if !m.Agrees() || len(m.Split()) != 0 {
+		t.Errorf("Agrees %v, Split %v", m.Agrees(), m.Split())
+	}
+}
+
+func TestSplit(t *testing.T) {
+	m := Identifier{Paths: []Path{
+		fixed(Win32, "real", nil),
+		fixed(Registry, "spoofed", nil),
+		fixed(Win32, "real", nil),
+		fixed(WMI, "other", nil),
+		fixed(Registry, "spoofed", nil),
+		fixed(Raw, "", errors.New("unsupported")),
+		fixed(NT, "real", nil),
+	}}.Run()
+	// Each dissenting layer once, in registration order; failed reads are not a split
+	if split := m.Split(); !slices.Equal(split, []Layer{Registry, WMI}) {
+		t.Errorf("Split = %v, want [Registry WMI]", split)
+	}
+	if m.Agrees() {
+		t.Error("three values reported as agreeing")
+	}
+}
+
+func TestPathName(t *testing.T) {
+	p := Path{DLL: "advapi32.dll", Export: "RegQueryValueExW"}
+	if got := p.Name(); got != "advapi32.dll!RegQueryValueExW" {
+		t.Errorf("Name = %q", got)
+	}
+	p.Detail = "MachineGuid"
+	if got := p.Name(); got != "advapi32.dll!RegQueryValueExW (MachineGuid)" {
+		t.Errorf("Name = %q", got)
+	}
+}
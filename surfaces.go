@@ -0,0 +1,220 @@
+//go:build windows
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/seekehr/DevSpoofGOTest/native"
+	"github.com/seekehr/DevSpoofGOTest/productkey"
+	"github.com/seekehr/DevSpoofGOTest/reg"
+	"github.com/seekehr/DevSpoofGOTest/surface"
+	"github.com/seekehr/DevSpoofGOTest/wmi"
+)
+
+var (
+	computerNameKey = native.RegistryIdentifier{Root: reg.LocalMachine, Path: `SYSTEM\CurrentControlSet\Control\ComputerName\ActiveComputerName`, Name: "ComputerName"}
+	machineGUIDKey  = native.RegistryIdentifier{Root: reg.LocalMachine, Path: `SOFTWARE\Microsoft\Cryptography`, Name: "MachineGuid"}
+	productIDKey    = native.RegistryIdentifier{Root: reg.LocalMachine, Path: `SOFTWARE\Microsoft\Windows NT\CurrentVersion`, Name: "ProductId"}
+)
+
+// apiSurfaces lists every identifier the -surface matrix checks and each call path it
+// can be read through. The SMBIOS paths parse the iteration's shared snapshot.
+func apiSurfaces(snapshot *native.SMBIOSSnapshot) []surface.Identifier {
+	rsmb := func(detail string, read func() (string, error)) surface.Path {
+		return surface.Path{DLL: "kernel32", Export: "GetSystemFirmwareTable", Charset: surface.Binary, Layer: surface.Raw, Detail: "RSMB " + detail, Read: read}
+	}
+
+	return []surface.Identifier{
+		{
+			Name: "Computer Name",
+			Paths: []surface.Path{
+				{DLL: "kernel32", Export: "GetComputerNameA", Charset: surface.ANSI, Layer: surface.Win32, Read: native.GetComputerNameA},
+				{DLL: "kernel32", Export: "GetComputerNameW", Charset: surface.Unicode, Layer: surface.Win32, Read: native.GetComputerNameW},
+				{DLL: "kernel32", Export: "GetComputerNameExW", Charset: surface.Unicode, Layer: surface.Win32, Detail: "ComputerNameNetBIOS", Read: func() (string, error) {
+					return native.GetComputerNameEx(0)
+				}},
+				{DLL: "kernel32", Export: "GetComputerNameExW", Charset: surface.Unicode, Layer: surface.Win32, Detail: "ComputerNamePhysicalNetBIOS", Read: func() (string, error) {
+					return native.GetComputerNameEx(4)
+				}},
+				{DLL: "ws2_32", Export: "gethostname", Charset: surface.ANSI, Layer: surface.Win32, Read: native.GetWinsockHostname},
+				registryPath(reg.Live{}, computerNameKey),
+				registryPath(reg.NT{}, computerNameKey),
+				{DLL: "wbem", Export: "Win32_ComputerSystem", Charset: surface.Unicode, Layer: surface.WMI, Detail: "Name", Read: wmi.GetComputerSystemName},
+			},
+			// gethostname returns the DNS host name, which NetBIOS truncates to 15 characters
+			Equal: func(a, b string) bool {
+				return strings.EqualFold(netBIOSPrefix(strings.TrimSpace(a)), netBIOSPrefix(strings.TrimSpace(b)))
+			},
+		},
+		{
+			Name: "BIOS Serial",
+			Paths: []surface.Path{
+				rsmb("Type 1", snapshot.BIOSSerial),
+				{DLL: "wbem", Export: "Win32_BIOS", Charset: surface.Unicode, Layer: surface.WMI, Detail: "SELECT SerialNumber", Read: func() (string, error) {
+					bios, err := wmi.GetBIOSSerial()
+					return bios.SerialNumber, err
+				}},
+				{DLL: "wbem", Export: "Win32_BIOS", Charset: surface.Unicode, Layer: surface.WMI, Detail: "all properties", Read: wmi.GetBIOSSerialFromAll},
+			},
+		},
+		{
+			Name: "Motherboard Serial",
+			Paths: []surface.Path{
+				rsmb("Type 2", snapshot.MotherboardSerial),
+				{DLL: "wbem", Export: "Win32_BaseBoard", Charset: surface.Unicode, Layer: surface.WMI, Detail: "SerialNumber", Read: wmi.GetBaseBoardSerial},
+			},
+		},
+		{
+			Name: "System UUID",
+			Paths: []surface.Path{
+				rsmb("Type 1", func() (string, error) {
+					uuid, err := snapshot.SystemUUID()
+					return uuid.String(), err
+				}),
+				{DLL: "wbem", Export: "Win32_ComputerSystemProduct", Charset: surface.Unicode, Layer: surface.WMI, Detail: "UUID", Read: wmi.GetComputerSystemProductUUID},
+			},
+			Equal: strings.EqualFold,
+		},
+		{
+			Name: "Processor ID",
+			Paths: []surface.Path{
+				rsmb("Type 4", func() (string, error) {
+					processors, err := snapshot.Processors()
+					if err != nil {
+						return "", err
+					}
+					if len(processors) == 0 {
+						return "", fmt.Errorf("processor information structure (Type 4) not found")
+					}
+					return processors[0].WMIProcessorID(), nil
+				}),
+				{DLL: "wbem", Export: "Win32_Processor", Charset: surface.Unicode, Layer: surface.WMI, Detail: "SELECT ProcessorId", Read: func() (string, error) {
+					processor, err := wmi.GetProcessorInfo()
+					return processor.ProcessorId, err
+				}},
+				{DLL: "wbem", Export: "Win32_Processor", Charset: surface.Unicode, Layer: surface.WMI, Detail: "all properties", Read: func() (string, error) {
+					processor, err := wmi.GetProcessorInfoFromAll()
+					return processor.ProcessorId, err
+				}},
+			},
+			Equal: strings.EqualFold,
+		},
+		{
+			Name: "Machine GUID",
+			Paths: []surface.Path{
+				registryPath(reg.Live{View: reg.View64}, machineGUIDKey),
+				registryPath(reg.Live{View: reg.View32}, machineGUIDKey),
+				registryPath(reg.NT{}, machineGUIDKey),
+			},
+			Equal: strings.EqualFold,
+		},
+		{
+			Name: "Product ID",
+			Paths: []surface.Path{
+				registryPath(reg.Live{}, productIDKey),
+				registryPath(reg.NT{}, productIDKey),
+				{DLL: "advapi32", Export: "RegQueryValueExW", Charset: surface.Binary, Layer: surface.Registry, Detail: "DigitalProductId", Read: func() (string, error) {
+					dpid, err := readRegistryBinary(reg.Live{}, productIDKey.Path, "DigitalProductId")
+					if err != nil {
+						return "", err
+					}
+					return productkey.ProductID(dpid)
+				}},
+				{DLL: "wbem", Export: "Win32_OperatingSystem", Charset: surface.Unicode, Layer: surface.WMI, Detail: "SerialNumber", Read: wmi.GetOperatingSystemSerial},
+			},
+		},
+		{
+			Name: "Volume Serial",
+			Paths: []surface.Path{
+				{DLL: "kernel32", Export: "GetVolumeInformationA", Charset: surface.ANSI, Layer: surface.Win32, Read: native.GetVolumeSerialA},
+				{DLL: "kernel32", Export: "GetVolumeInformationW", Charset: surface.Unicode, Layer: surface.Win32, Read: native.GetVolumeSerialW},
+			},
+		},
+	}
+}
+
+// registryPath reads id through r: advapi32 for the live registry (in its view, if
+// one is set) or ntdll for the NT backend.
+func registryPath(r reg.Registry, id native.RegistryIdentifier) surface.Path {
+	path := surface.Path{DLL: "advapi32", Export: "RegQueryValueExW", Charset: surface.Unicode, Layer: surface.Registry, Detail: id.Name}
+	switch r := r.(type) {
+	case reg.NT:
+		path.DLL, path.Export, path.Layer = "ntdll", "NtQueryValueKey", surface.NT
+	case reg.Live:
+		if r.View != reg.DefaultView {
+			path.Detail += ", " + r.View.String()
+		}
+	}
+	path.Read = func() (string, error) {
+		value, err := native.ReadRegistryIdentifier(r, id)
+		if err != nil {
+			return "", err
+		}
+		return value.String(), nil
+	}
+	return path
+}
+
+// readRegistryBinary reads one REG_BINARY value under HKEY_LOCAL_MACHINE from r.
+func readRegistryBinary(r reg.Registry, path, name string) ([]byte, error) {
+	k, err := r.OpenKey(reg.LocalMachine, path)
+	if err != nil {
+		return nil, err
+	}
+	defer k.Close()
+
+	return k.BinaryValue(name)
+}
+
+// outputAPISurfaces runs every path of every identifier and prints one matrix per
+// identifier: a row per path with its DLL, export, charset and layer, the value it
+// returned and which agreement group that value falls in. Values outside the majority
+// group are red.
+func outputAPISurfaces(snapshot *native.SMBIOSSnapshot) {
+	str := green("=====API Surface Matrix=====")
+	for _, id := range apiSurfaces(snapshot) {
+		m := id.Run()
+		str += "\n" + cyan("====="+m.Identifier+"=====")
+		switch {
+		case m.Groups == 0:
+			str += " " + red("every path failed")
+		case m.Agrees():
+			str += " " + green(fmt.Sprintf("all %d paths agree", len(m.Results)-m.Failed()))
+		default:
+			layers := make([]string, 0, len(m.Split()))
+			for _, layer := range m.Split() {
+				layers = append(layers, string(layer))
+			}
+			str += " " + red(fmt.Sprintf("MISMATCH (%d values, split in %s)", m.Groups, strings.Join(layers, ", ")))
+		}
+		if failed := m.Failed(); failed > 0 && m.Groups > 0 {
+			str += " " + red(fmt.Sprintf("%d failed", failed))
+		}
+
+		width := 0
+		for _, r := range m.Results {
+			width = max(width, len(r.Path.Name()))
+		}
+		majority := m.Majority()
+		for _, r := range m.Results {
+			group := "!"
+			if r.Group >= 0 {
+				group = string(rune('a' + r.Group%26))
+			}
+			row := fmt.Sprintf("\n[%s] %-*s  %-8s %-2s ", group, width, r.Path.Name(), r.Path.Layer, r.Path.Charset)
+			switch {
+			case r.Err != nil:
+				str += row + red("Error ("+r.Err.Error()+")")
+			case r.Group != majority:
+				str += row + red(r.Value)
+			case r.Value == "":
+				str += row + cyan("Not Specified")
+			default:
+				str += row + r.Value
+			}
+		}
+	}
+	fmt.Println(str)
+}
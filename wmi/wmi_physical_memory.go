@@ -1,3 +1,5 @@
+//go:build windows
+
 package wmi
 
 import (
@@ -36,3 +38,25 @@ func GetPhysicalMemoryInfoFromAll() (Win32_PhysicalMemory, error) {
 
 	return physicalMemory[0], nil
 }
+
+// PhysicalMemoryDevice is a Win32_PhysicalMemory instance with the fields needed
+// to pair it with its SMBIOS Type 17 memory device.
+type PhysicalMemoryDevice struct {
+	DeviceLocator string
+	BankLabel     string
+	Capacity      uint64
+	Speed         uint32
+	Manufacturer  string
+	SerialNumber  string
+	PartNumber    string
+}
+
+// GetPhysicalMemoryDevices returns every Win32_PhysicalMemory instance.
+func GetPhysicalMemoryDevices() ([]PhysicalMemoryDevice, error) {
+	var devices []PhysicalMemoryDevice
+	err := wmi.Query("SELECT DeviceLocator, BankLabel, Capacity, Speed, Manufacturer, SerialNumber, PartNumber FROM Win32_PhysicalMemory", &devices)
+	if err != nil {
+		return nil, fmt.Errorf("WMI query failed: %w", err)
+	}
+	return devices, nil
+}
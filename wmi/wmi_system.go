@@ -0,0 +1,77 @@
+//go:build windows
+
+package wmi
+
+import (
+	"fmt"
+	"github.com/yusufpapurcu/wmi"
+)
+
+type Win32_ComputerSystem struct {
+	Name string
+}
+
+type Win32_BaseBoard struct {
+	SerialNumber string
+}
+
+type Win32_ComputerSystemProduct struct {
+	UUID string
+}
+
+type Win32_OperatingSystem struct {
+	SerialNumber string
+}
+
+// GetComputerSystemName returns Win32_ComputerSystem.Name, the NetBIOS computer name.
+func GetComputerSystemName() (string, error) {
+	var system []Win32_ComputerSystem
+	err := wmi.Query("SELECT Name FROM Win32_ComputerSystem", &system)
+	if err != nil {
+		return "", fmt.Errorf("WMI query failed: %w", err)
+	}
+	if len(system) == 0 {
+		return "", fmt.Errorf("no computer system information found")
+	}
+	return system[0].Name, nil
+}
+
+// GetBaseBoardSerial returns Win32_BaseBoard.SerialNumber (SMBIOS Type 2).
+func GetBaseBoardSerial() (string, error) {
+	var baseboard []Win32_BaseBoard
+	err := wmi.Query("SELECT SerialNumber FROM Win32_BaseBoard", &baseboard)
+	if err != nil {
+		return "", fmt.Errorf("WMI query failed: %w", err)
+	}
+	if len(baseboard) == 0 {
+		return "", fmt.Errorf("no baseboard information found")
+	}
+	return baseboard[0].SerialNumber, nil
+}
+
+// GetComputerSystemProductUUID returns Win32_ComputerSystemProduct.UUID (SMBIOS Type 1).
+func GetComputerSystemProductUUID() (string, error) {
+	var product []Win32_ComputerSystemProduct
+	err := wmi.Query("SELECT UUID FROM Win32_ComputerSystemProduct", &product)
+	if err != nil {
+		return "", fmt.Errorf("WMI query failed: %w", err)
+	}
+	if len(product) == 0 {
+		return "", fmt.Errorf("no computer system product information found")
+	}
+	return product[0].UUID, nil
+}
+
+// GetOperatingSystemSerial returns Win32_OperatingSystem.SerialNumber, which is the
+// registry ProductId.
+func GetOperatingSystemSerial() (string, error) {
+	var os []Win32_OperatingSystem
+	err := wmi.Query("SELECT SerialNumber FROM Win32_OperatingSystem", &os)
+	if err != nil {
+		return "", fmt.Errorf("WMI query failed: %w", err)
+	}
+	if len(os) == 0 {
+		return "", fmt.Errorf("no operating system information found")
+	}
+	return os[0].SerialNumber, nil
+}